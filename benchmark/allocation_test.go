@@ -0,0 +1,82 @@
+package benchmark
+
+import (
+	"github.com/SoulKa/golymorph"
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+	"testing"
+)
+
+// TestAllocationBudget fails if a hot path of the resolve and decode pipeline allocates more than its budget.
+// Lower the budgets when optimizing; only raise them with a good reason. The budgets are exact for normal builds, so
+// the test is skipped under the race detector.
+func TestAllocationBudget(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation budgets do not apply with the race detector")
+	}
+	deepPath := *objectpath.MustParse(deepPathString())
+	var deepMap any = newDeepMap()
+	var deepStruct any = newDeepStruct()
+	deepNode := newDeepStruct()
+	compiled, err := objectpath.Compile(deepPath, reflect.TypeOf(deepNode))
+	if err != nil {
+		t.Fatalf("error compiling path: %s", err)
+	}
+	typeMapPolymorphism := newTypeMapPolymorphism()
+	rulePolymorphism := newRulePolymorphism()
+	source := newEventSource()
+	var value reflect.Value
+
+	type TestCase struct {
+		name   string
+		budget float64
+		run    func() error
+	}
+	testCases := []TestCase{
+		{"Parse", 25, func() error {
+			_, err := objectpath.Parse("payload/type")
+			return err
+		}},
+		{"GetValueAtPath/DeepMap", 2*depth + 2, func() error {
+			return objectpath.GetValueAtPath(&deepMap, deepPath, &value)
+		}},
+		{"GetValueAtPath/DeepStruct", 0, func() error {
+			return objectpath.GetValueAtPath(&deepStruct, deepPath, &value)
+		}},
+		{"CompiledPath.GetValue", 0, func() error {
+			return compiled.GetValue(&deepNode, &value)
+		}},
+		{"TypeMapPolymorphism.AssignTargetType", 7, func() error {
+			var event Event
+			return typeMapPolymorphism.AssignTargetType(&source, &event)
+		}},
+		{"RulePolymorphism.AssignTargetType", 4*ruleCount + 3, func() error {
+			var event Event
+			return rulePolymorphism.AssignTargetType(&source, &event)
+		}},
+		{"UnmarshalJSON", 56, func() error {
+			var event Event
+			return golymorph.UnmarshalJSON(typeMapPolymorphism, eventJson, &event)
+		}},
+		{"UnmarshalJSONFast", 33, func() error {
+			var event Event
+			return golymorph.UnmarshalJSONFast(typeMapPolymorphism, eventJson, &event)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var err error
+			allocs := testing.AllocsPerRun(100, func() {
+				if runErr := tc.run(); runErr != nil {
+					err = runErr
+				}
+			})
+			if err != nil {
+				t.Fatalf("error running %s: %s", tc.name, err)
+			} else if allocs > tc.budget {
+				t.Fatalf("expected at most %.0f allocations, but got %.1f", tc.budget, allocs)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/SoulKa/golymorph"
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// depth is the nesting depth of the deep maps and structs
+const depth = 16
+
+// ruleCount is the number of rules and type map entries of the polymorphisms
+const ruleCount = 100
+
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+type Event struct {
+	Timestamp string
+	Payload   any
+}
+
+type AlertPayload struct {
+	Type    string
+	Message string
+}
+
+var eventJson = []byte(`{ "timestamp": "2023-11-27T22:14:09+00:00", "payload": { "type": "alert", "message": "something is broken!" } }`)
+
+// deepPathString returns a path of depth times "next", followed by "value"
+func deepPathString() string {
+	return strings.Repeat("next/", depth) + "value"
+}
+
+// newDeepMap returns nested maps that contain a value at deepPathString
+func newDeepMap() map[string]any {
+	node := map[string]any{"value": 1}
+	for i := 0; i < depth; i++ {
+		node = map[string]any{"next": node}
+	}
+	return node
+}
+
+// newDeepStruct returns nested Nodes that contain a value at deepPathString
+func newDeepStruct() Node {
+	node := &Node{Value: 1}
+	for i := 0; i < depth; i++ {
+		node = &Node{Next: node}
+	}
+	return *node
+}
+
+// newTypeMapPolymorphism returns a TypeMapPolymorphism with ruleCount type map entries
+func newTypeMapPolymorphism() golymorph.TypeResolver {
+	typeMap := golymorph.TypeMap{"alert": reflect.TypeOf(AlertPayload{})}
+	for i := 0; i < ruleCount-1; i++ {
+		typeMap[fmt.Sprintf("type%d", i)] = reflect.TypeOf(i)
+	}
+	return golymorph.Must(golymorph.NewPolymorphismBuilder().
+		DefineTypeAt("payload").
+		UsingTypeMap(typeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+}
+
+// newRulePolymorphism returns a RulePolymorphism with ruleCount rules of which only the last one matches
+func newRulePolymorphism() golymorph.TypeResolver {
+	var builder golymorph.PolymorphismRuleAdder
+	for i := 0; i < ruleCount-1; i++ {
+		rule := golymorph.Must(golymorph.NewRuleBuilder().
+			WhenValueAt("payload/type").
+			IsEqualTo(fmt.Sprintf("type%d", i)).
+			ThenAssignType(reflect.TypeOf(i)).
+			BuildRule())
+		if builder == nil {
+			builder = golymorph.NewPolymorphismBuilder().DefineTypeAt("payload").UsingRule(rule)
+		} else {
+			builder = builder.UsingRule(rule)
+		}
+	}
+	return golymorph.Must(builder.UsingRule(golymorph.Must(golymorph.NewRuleBuilder().
+		WhenValueAt("payload/type").
+		IsEqualTo("alert").
+		ThenAssignType(reflect.TypeOf(AlertPayload{})).
+		BuildRule())).
+		BuildResolver())
+}
+
+// newEventSource returns the parsed eventJson
+func newEventSource() map[string]any {
+	var source map[string]any
+	if err := json.Unmarshal(eventJson, &source); err != nil {
+		panic(err)
+	}
+	return source
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, s := range []string{"payload/type", `/"foo"/bar/../"baz\"qux"/./quux`, deepPathString()} {
+		b.Run(s, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := objectpath.Parse(s); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetValueAtPath(b *testing.B) {
+	path := *objectpath.MustParse(deepPathString())
+	sources := map[string]any{
+		"DeepMap":    newDeepMap(),
+		"DeepStruct": newDeepStruct(),
+	}
+	for name, source := range sources {
+		b.Run(name, func(b *testing.B) {
+			var value reflect.Value
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := objectpath.GetValueAtPath(&source, path, &value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCompiledPath_GetValue(b *testing.B) {
+	source := newDeepStruct()
+	compiled, err := objectpath.Compile(*objectpath.MustParse(deepPathString()), reflect.TypeOf(source))
+	if err != nil {
+		b.Fatal(err)
+	}
+	var value reflect.Value
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := compiled.GetValue(&source, &value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAssignTargetType(b *testing.B) {
+	resolvers := map[string]golymorph.TypeResolver{
+		"TypeMapPolymorphism": newTypeMapPolymorphism(),
+		"RulePolymorphism":    newRulePolymorphism(),
+	}
+	for name, resolver := range resolvers {
+		b.Run(name, func(b *testing.B) {
+			source := newEventSource()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var event Event
+				if err := resolver.AssignTargetType(&source, &event); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	resolver := newTypeMapPolymorphism()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var event Event
+		if err := golymorph.UnmarshalJSON(resolver, eventJson, &event); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalJSONFast(b *testing.B) {
+	resolver := newTypeMapPolymorphism()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var event Event
+		if err := golymorph.UnmarshalJSONFast(resolver, eventJson, &event); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package benchmark contains the benchmarks and allocation budgets of the resolve and decode pipeline of golymorph.
+// It has no API and only consists of tests. Run the benchmarks with `go test -bench . ./benchmark`.
+package benchmark
@@ -0,0 +1,6 @@
+//go:build !race
+
+package benchmark
+
+// raceEnabled is true if the tests run with the race detector, which adds allocations of its own
+const raceEnabled = false
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Config is the declaration of the polymorphisms to generate code for. It is read from a JSON file.
+type Config struct {
+	// Package is the name of the package of the generated file
+	Package string `json:"package"`
+	// Polymorphisms are the polymorphisms to generate code for
+	Polymorphisms []PolymorphismConfig `json:"polymorphisms"`
+}
+
+// PolymorphismConfig declares a polymorphic field of a parent type. It corresponds to a TypeMapPolymorphism
+// built with golymorph.NewPolymorphismBuilder().DefineTypeAt(TargetPath).UsingTypeMap(...).WithDiscriminatorAt(DiscriminatorPath).
+type PolymorphismConfig struct {
+	// Type is the name of the parent type that contains the polymorphic field
+	Type string `json:"type"`
+	// Field is the name of the polymorphic field of the parent type
+	Field string `json:"field"`
+	// JSONName is the name of the polymorphic field in the json struct tag. It defaults to Field.
+	JSONName string `json:"jsonName"`
+	// TargetPath is the key of the polymorphic field in the JSON document. It must consist of a single element.
+	TargetPath string `json:"targetPath"`
+	// DiscriminatorPath is the path to the discriminator value. Relative paths are relative to TargetPath.
+	DiscriminatorPath string `json:"discriminatorPath"`
+	// Variants map the discriminator values to the types of the polymorphic field
+	Variants []VariantConfig `json:"variants"`
+}
+
+// VariantConfig maps a discriminator value to a type.
+type VariantConfig struct {
+	// Value is the discriminator value. As in the runtime TypeMap, JSON numbers are float64 values.
+	Value any `json:"value"`
+	// Type is the Go type expression of the variant, e.g. AlertPayload or *AlertPayload
+	Type string `json:"type"`
+}
+
+// ReadConfig reads and validates the Config in the given file.
+func ReadConfig(fileName string) (*Config, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing config [%s]: %w", fileName, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config [%s]: %w", fileName, err)
+	}
+	return &config, nil
+}
+
+// Validate returns an error if a required value of the Config is missing.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Package == "" {
+		errs = append(errs, errors.New("package is missing"))
+	}
+	for i, p := range c.Polymorphisms {
+		if p.Type == "" || p.Field == "" || p.TargetPath == "" || p.DiscriminatorPath == "" {
+			errs = append(errs, fmt.Errorf("polymorphism %d: type, field, targetPath and discriminatorPath are required", i))
+		}
+		for j, v := range p.Variants {
+			if v.Type == "" {
+				errs = append(errs, fmt.Errorf("polymorphism %d: variant %d: type is missing", i, j))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
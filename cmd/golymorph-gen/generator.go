@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/SoulKa/golymorph/objectpath"
+	"go/format"
+	"sort"
+	"strconv"
+	"text/template"
+)
+
+// caseData is a single case of a generated type switch
+type caseData struct {
+	Literal string
+	Type    string
+}
+
+// polymorphismData is the template data of a single polymorphism
+type polymorphismData struct {
+	Type                    string
+	Field                   string
+	JSONName                string
+	TargetPath              string
+	DiscriminatorPath       string
+	DiscriminatorKeys       []string
+	DiscriminatorKey        string
+	DiscriminatorCases      []caseData
+	KnownKeys               string
+	TypeCases               []caseData
+	GenerateMarshalFunction bool
+}
+
+// fileData is the template data of a generated file
+type fileData struct {
+	Package       string
+	Polymorphisms []polymorphismData
+	HasMarshal    bool
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by golymorph-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	golymorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	{{- if .HasMarshal}}
+	"strings"
+	{{- end}}
+)
+{{range $p := .Polymorphisms}}
+// UnmarshalJSON implements json.Unmarshaler. The type of {{.Field}} is determined by the discriminator at [{{.DiscriminatorPath}}].
+func (v *{{.Type}}) UnmarshalJSON(data []byte) error {
+	type plain {{.Type}}
+	raw := struct {
+		*plain
+		{{.Field}} json.RawMessage ` + "`json:\"{{.JSONName}}\"`" + `
+	}{plain: (*plain)(v)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	// get discriminator value
+	discriminator, err := golymorphLookup(data, {{printf "%q" .DiscriminatorPath}}{{range .DiscriminatorKeys}}, {{printf "%q" .}}{{end}})
+	if err != nil {
+		return &golymorphError.DiscriminatorMissingError{DiscriminatorPath: {{printf "%q" .DiscriminatorPath}}, Err: err}
+	}
+
+	// decode the variant
+	switch discriminator {
+	{{- range .DiscriminatorCases}}
+	case {{.Literal}}:
+		var variant {{.Type}}
+		if err := golymorphDecode(raw.{{$p.Field}}, &variant); err != nil {
+			return err
+		}
+		v.{{$p.Field}} = variant
+	{{- end}}
+	default:
+		return &golymorphError.UnresolvedTypeError{
+			Err:        &golymorphError.UnknownDiscriminatorError{Value: discriminator, KnownKeys: []any{ {{- .KnownKeys -}} }},
+			TargetPath: {{printf "%q" .TargetPath}},
+		}
+	}
+	return nil
+}
+{{if .GenerateMarshalFunction}}
+// MarshalJSON implements json.Marshaler. The discriminator at [{{.DiscriminatorPath}}] is set according to the type of {{.Field}}.
+func (v {{.Type}}) MarshalJSON() ([]byte, error) {
+	type plain {{.Type}}
+	var discriminator any
+	switch v.{{.Field}}.(type) {
+	{{- range .TypeCases}}
+	case {{.Type}}:
+		discriminator = {{.Literal}}
+	{{- end}}
+	default:
+		return json.Marshal(plain(v))
+	}
+	variant, err := json.Marshal(v.{{.Field}})
+	if err != nil {
+		return nil, err
+	}
+	if variant, err = golymorphSetKey(variant, {{printf "%q" .DiscriminatorKey}}, discriminator); err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		plain
+		{{.Field}} json.RawMessage ` + "`json:\"{{.JSONName}}\"`" + `
+	}{plain(v), variant})
+}
+{{end}}
+{{- end}}
+// golymorphLookup returns the JSON value at the given keys of data. The errors match the ones of objectpath.GetValueAtPath.
+func golymorphLookup(data []byte, path string, keys ...string) (any, error) {
+	raw := json.RawMessage(data)
+	for i, key := range keys {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, &golymorphError.NotTraversableError{Path: path, Index: i, Kind: golymorphKind(raw)}
+		} else if object == nil {
+			return nil, &golymorphError.PathNotFoundError{Path: path, Index: i, Element: key, Kind: reflect.Invalid}
+		} else if raw = object[key]; raw == nil {
+			return nil, &golymorphError.PathNotFoundError{Path: path, Index: i, Element: key, Kind: reflect.Map}
+		}
+	}
+	var value any
+	err := json.Unmarshal(raw, &value)
+	return value, err
+}
+
+// golymorphKind returns the kind of the value that encoding/json decodes raw into when decoding into an interface.
+func golymorphKind(raw json.RawMessage) reflect.Kind {
+	for _, c := range raw {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return reflect.Map
+		case '[':
+			return reflect.Slice
+		case '"':
+			return reflect.String
+		case 't', 'f':
+			return reflect.Bool
+		case 'n':
+			return reflect.Invalid
+		default:
+			return reflect.Float64
+		}
+	}
+	return reflect.Invalid
+}
+
+// golymorphDecode decodes raw into variant. A missing value leaves variant untouched.
+func golymorphDecode(raw json.RawMessage, variant any) error {
+	if raw == nil {
+		return nil
+	}
+	return json.Unmarshal(raw, variant)
+}
+{{- if .HasMarshal}}
+
+// golymorphSetKey sets the given key and all keys that equal it case-insensitively to value if raw is a JSON object.
+func golymorphSetKey(raw json.RawMessage, key string, value any) (json.RawMessage, error) {
+	var object map[string]any
+	if err := json.Unmarshal(raw, &object); err != nil || object == nil {
+		return raw, nil
+	}
+	for k := range object {
+		if strings.EqualFold(k, key) {
+			object[k] = value
+		}
+	}
+	object[key] = value
+	return json.Marshal(object)
+}
+{{- end}}
+`))
+
+// Generate returns the formatted Go source code implementing the polymorphisms of the given Config.
+func Generate(config *Config) ([]byte, error) {
+	data := fileData{Package: config.Package}
+	for i, p := range config.Polymorphisms {
+		polymorphism, err := newPolymorphismData(p)
+		if err != nil {
+			return nil, fmt.Errorf("polymorphism %d (%s.%s): %w", i, p.Type, p.Field, err)
+		}
+		data.Polymorphisms = append(data.Polymorphisms, *polymorphism)
+		data.HasMarshal = data.HasMarshal || polymorphism.GenerateMarshalFunction
+	}
+
+	var buffer bytes.Buffer
+	if err := fileTemplate.Execute(&buffer, data); err != nil {
+		return nil, err
+	}
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w", err)
+	}
+	return source, nil
+}
+
+// newPolymorphismData resolves the paths of the given PolymorphismConfig just like the polymorphism builder does.
+func newPolymorphismData(config PolymorphismConfig) (*polymorphismData, error) {
+	targetPath, err := objectpath.Parse("/" + config.TargetPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target path: %w", err)
+	}
+	targetElements := targetPath.Elements()
+	if len(targetElements) != 1 || targetElements[0].Type() != objectpath.ElementTypeIdentifier {
+		return nil, fmt.Errorf("target path [%s] must consist of a single identifier", config.TargetPath)
+	}
+	discriminatorPath, err := objectpath.Parse(config.DiscriminatorPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid discriminator path: %w", err)
+	} else if err := discriminatorPath.ToAbsolutePath(targetPath); err != nil {
+		return nil, fmt.Errorf("invalid discriminator path: %w", err)
+	}
+
+	data := &polymorphismData{
+		Type:              config.Type,
+		Field:             config.Field,
+		JSONName:          config.JSONName,
+		TargetPath:        targetPath.String(),
+		DiscriminatorPath: discriminatorPath.String(),
+	}
+	if data.JSONName == "" {
+		data.JSONName = config.Field
+	}
+	discriminatorElements := discriminatorPath.Elements()
+	for _, element := range discriminatorElements {
+		if element.Type() != objectpath.ElementTypeIdentifier {
+			return nil, fmt.Errorf("discriminator path [%s] must only consist of identifiers", config.DiscriminatorPath)
+		}
+		data.DiscriminatorKeys = append(data.DiscriminatorKeys, element.Name())
+	}
+	if len(discriminatorElements) == 2 && discriminatorElements[0] == targetElements[0] {
+		data.DiscriminatorKey = discriminatorElements[1].Name()
+		data.GenerateMarshalFunction = true
+	}
+
+	// create the switch cases
+	literals := make(map[string]bool)
+	types := make(map[string]bool)
+	var knownKeys []any
+	for _, variant := range config.Variants {
+		literal, err := goLiteral(variant.Value)
+		if err != nil {
+			return nil, err
+		} else if literals[literal] {
+			return nil, fmt.Errorf("duplicate discriminator value %s", literal)
+		}
+		literals[literal] = true
+		knownKeys = append(knownKeys, variant.Value)
+		data.DiscriminatorCases = append(data.DiscriminatorCases, caseData{literal, variant.Type})
+		if !types[variant.Type] {
+			types[variant.Type] = true
+			data.TypeCases = append(data.TypeCases, caseData{literal, variant.Type})
+		}
+	}
+	sort.Slice(knownKeys, func(i, j int) bool { return fmt.Sprint(knownKeys[i]) < fmt.Sprint(knownKeys[j]) })
+	for i, key := range knownKeys {
+		literal, _ := goLiteral(key)
+		if i > 0 {
+			data.KnownKeys += ", "
+		}
+		data.KnownKeys += literal
+	}
+	return data, nil
+}
+
+// goLiteral returns the Go literal of a JSON value that has the same dynamic type as the value decoded by encoding/json.
+func goLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case float64:
+		return "float64(" + strconv.FormatFloat(v, 'g', -1, 64) + ")", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("unsupported discriminator value [%v] of type %T", value, value)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+
+	// Arrange
+	config, err := ReadConfig("internal/example/golymorph.json")
+	if err != nil {
+		t.Fatalf("error reading config: %s", err)
+	}
+	expected, err := os.ReadFile("internal/example/golymorph_gen.go")
+	if err != nil {
+		t.Fatalf("error reading generated code: %s", err)
+	}
+
+	// Act
+	actual, err := Generate(config)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error generating code: %s", err)
+	} else if !bytes.Equal(actual, expected) {
+		t.Fatalf("generated code of the example is outdated. Run go generate ./...")
+	}
+}
+
+func TestGenerateWithError(t *testing.T) {
+	type TestCase struct {
+		name   string
+		config PolymorphismConfig
+		error  string
+	}
+	testCases := []TestCase{
+		{"invalid target path", PolymorphismConfig{Type: "Event", Field: "Payload", TargetPath: "pay#load", DiscriminatorPath: "type"},
+			`polymorphism 0 (Event.Payload): invalid target path: unexpected character [#] at index 4. A non-enclosed path may only contain letters, digits, underscores and hyphens`},
+		{"nested target path", PolymorphismConfig{Type: "Event", Field: "Payload", TargetPath: "data/payload", DiscriminatorPath: "type"},
+			`polymorphism 0 (Event.Payload): target path [data/payload] must consist of a single identifier`},
+		{"filtered discriminator path", PolymorphismConfig{Type: "Event", Field: "Payload", TargetPath: "payload", DiscriminatorPath: `attributes[?(@.name=="kind")]/value`},
+			`polymorphism 0 (Event.Payload): discriminator path [attributes[?(@.name=="kind")]/value] must only consist of identifiers`},
+		{"duplicate value", PolymorphismConfig{Type: "Event", Field: "Payload", TargetPath: "payload", DiscriminatorPath: "type",
+			Variants: []VariantConfig{{"alert", "AlertPayload"}, {"alert", "PingPayload"}}},
+			`polymorphism 0 (Event.Payload): duplicate discriminator value "alert"`},
+		{"unsupported value", PolymorphismConfig{Type: "Event", Field: "Payload", TargetPath: "payload", DiscriminatorPath: "type",
+			Variants: []VariantConfig{{[]any{}, "AlertPayload"}}},
+			`polymorphism 0 (Event.Payload): unsupported discriminator value [[]] of type []interface {}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{Package: "example", Polymorphisms: []PolymorphismConfig{tc.config}}
+			if _, err := Generate(&config); err == nil {
+				t.Fatalf("expected error, but got none")
+			} else if err.Error() != tc.error {
+				t.Fatalf(`expected error to be [%s], but got [%s]`, tc.error, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Package example demonstrates the code generated by golymorph-gen and verifies that it behaves like the runtime
+// TypeMapPolymorphism.
+package example
+
+//go:generate go run github.com/SoulKa/golymorph/cmd/golymorph-gen -config golymorph.json -output golymorph_gen.go
+
+// Event is the parent type that contains the polymorphic payload
+type Event struct {
+	Timestamp string `json:"timestamp"`
+	Payload   any    `json:"payload"`
+}
+
+// AlertPayload is the payload of an alert Event
+type AlertPayload struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// PingPayload is the payload of a ping Event
+type PingPayload struct {
+	Type string `json:"type"`
+	Ip   string `json:"ip"`
+}
@@ -0,0 +1,71 @@
+package example
+
+import (
+	"encoding/json"
+	"github.com/SoulKa/golymorph"
+	"reflect"
+	"testing"
+)
+
+var resolver = golymorph.Must(golymorph.NewPolymorphismBuilder().
+	DefineTypeAt("payload").
+	UsingTypeMap(golymorph.TypeMap{
+		"alert": reflect.TypeOf(AlertPayload{}),
+		"ping":  reflect.TypeOf(PingPayload{}),
+	}).
+	WithDiscriminatorAt("type").
+	BuildResolver())
+
+func TestGeneratedUnmarshalJSON(t *testing.T) {
+	testCases := []string{
+		`{ "timestamp": "2023-11-27T22:14:09+00:00", "payload": { "type": "alert", "message": "something is broken!" } }`,
+		`{ "timestamp": "2023-11-27T22:14:09+00:00", "payload": { "type": "ping", "ip": "127.0.0.1" } }`,
+		`{ "timestamp": "2023-11-27T22:14:09+00:00", "payload": { "type": "alrt" } }`,
+		`{ "timestamp": "2023-11-27T22:14:09+00:00", "payload": { "message": "something is broken!" } }`,
+		`{ "timestamp": "2023-11-27T22:14:09+00:00", "payload": "alert" }`,
+		`{ "timestamp": "2023-11-27T22:14:09+00:00" }`,
+		`{ "timestamp": "2023-11-27T22:14:09+00:00", "payload": null }`,
+		`{ "timestamp": "2023-11-27T22:14:09+00:00", "payload": { "type": null } }`,
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+
+			// Act
+			var expected, actual Event
+			expectedErr := golymorph.UnmarshalJSON(resolver, []byte(tc), &expected)
+			actualErr := json.Unmarshal([]byte(tc), &actual)
+
+			// Assert
+			if expectedErr != nil || actualErr != nil {
+				if !reflect.DeepEqual(actualErr, expectedErr) {
+					t.Fatalf("expected error to be [%#v], but got [%#v]", expectedErr, actualErr)
+				}
+			} else if !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("expected event to be %+v, but got %+v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestGeneratedMarshalJSON(t *testing.T) {
+
+	// Arrange
+	expected := Event{Timestamp: "2023-11-27T22:14:09+00:00", Payload: PingPayload{Ip: "127.0.0.1"}}
+
+	// Act
+	data, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatalf("error marshalling event: %s", err)
+	}
+	var actual Event
+	if err := golymorph.UnmarshalJSON(resolver, data, &actual); err != nil {
+		t.Fatalf("error unmarshalling event %s: %s", data, err)
+	}
+
+	// Assert
+	expected.Payload = PingPayload{Type: "ping", Ip: "127.0.0.1"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected event to be %+v, but got %+v", expected, actual)
+	}
+}
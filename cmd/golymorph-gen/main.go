@@ -0,0 +1,36 @@
+// Command golymorph-gen generates reflection-free UnmarshalJSON and MarshalJSON methods for polymorphic types.
+// The generated code produces the same results and errors as a golymorph.TypeMapPolymorphism. The polymorphisms
+// are declared in a JSON file, see Config. Usage with go generate:
+//
+//	//go:generate go run github.com/SoulKa/golymorph/cmd/golymorph-gen -config golymorph.json -output golymorph_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	configFileName := flag.String("config", "golymorph.json", "the JSON file declaring the polymorphisms")
+	outputFileName := flag.String("output", "golymorph_gen.go", "the Go file to write the generated code to")
+	flag.Parse()
+
+	if err := run(*configFileName, *outputFileName); err != nil {
+		fmt.Fprintf(os.Stderr, "golymorph-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the code for the given config file and writes it to the given output file.
+func run(configFileName string, outputFileName string) error {
+	config, err := ReadConfig(configFileName)
+	if err != nil {
+		return err
+	}
+	source, err := Generate(config)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputFileName, source, 0644)
+}
@@ -0,0 +1,38 @@
+package error
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AggregateError is an error that contains all errors that occurred while resolving types in error collection mode
+type AggregateError struct {
+	// Errors are the errors that occurred, in the order they occurred
+	Errors []*ResolutionError
+}
+
+func (e *AggregateError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred while resolving types:\n%s", len(e.Errors), strings.Join(messages, "\n"))
+}
+
+// Unwrap returns the errors that occurred, so that errors.Is and errors.As match any of them
+func (e *AggregateError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Locations returns the locations of all errors
+func (e *AggregateError) Locations() []string {
+	locations := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		locations[i] = err.Location
+	}
+	return locations
+}
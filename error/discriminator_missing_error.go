@@ -0,0 +1,24 @@
+package error
+
+// DiscriminatorMissingError is an error that occurs when the discriminator value of a polymorphism cannot be read
+// from the source
+type DiscriminatorMissingError struct {
+	// DiscriminatorPath is the string representation of the path to the discriminator
+	DiscriminatorPath string
+	// Err is the error that occurred when reading the discriminator, usually a PathNotFoundError or NotTraversableError
+	Err error
+}
+
+func (e *DiscriminatorMissingError) Error() string {
+	return "error getting discriminator value\n" + e.Err.Error()
+}
+
+// Unwrap returns the error that occurred when reading the discriminator
+func (e *DiscriminatorMissingError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if target is ErrDiscriminatorMissing
+func (e *DiscriminatorMissingError) Is(target error) bool {
+	return target == ErrDiscriminatorMissing
+}
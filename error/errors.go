@@ -0,0 +1,28 @@
+package error
+
+import "errors"
+
+// Sentinel errors that the errors of this package match with errors.Is. They allow branching on the cause of an
+// error without depending on its concrete type, e.g. errors.Is(err, ErrUnknownDiscriminator).
+var (
+	// ErrUnresolvedType is matched by UnresolvedTypeError
+	ErrUnresolvedType = errors.New("unresolved type")
+	// ErrPathNotFound is matched by PathNotFoundError
+	ErrPathNotFound = errors.New("path not found")
+	// ErrNotTraversable is matched by NotTraversableError
+	ErrNotTraversable = errors.New("value not traversable")
+	// ErrDiscriminatorMissing is matched by DiscriminatorMissingError
+	ErrDiscriminatorMissing = errors.New("discriminator missing")
+	// ErrUnknownDiscriminator is matched by UnknownDiscriminatorError
+	ErrUnknownDiscriminator = errors.New("unknown discriminator")
+	// ErrTypeNotAssignable is matched by TypeNotAssignableError
+	ErrTypeNotAssignable = errors.New("type not assignable")
+	// ErrUnhandledType is matched by UnhandledTypeError
+	ErrUnhandledType = errors.New("unhandled type")
+	// ErrVariantMismatch is matched by VariantMismatchError
+	ErrVariantMismatch = errors.New("variant mismatch")
+	// ErrKeyConversion is matched by KeyConversionError
+	ErrKeyConversion = errors.New("key conversion failed")
+	// ErrLimitExceeded is matched by LimitExceededError
+	ErrLimitExceeded = errors.New("limit exceeded")
+)
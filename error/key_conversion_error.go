@@ -0,0 +1,34 @@
+package error
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// KeyConversionError is an error that occurs when a path element cannot be converted to the key type of a map
+type KeyConversionError struct {
+	// Path is the string representation of the path
+	Path string
+	// Index is the index of the path element that could not be converted
+	Index int
+	// Element is the name of the path element that could not be converted
+	Element string
+	// KeyType is the key type of the map
+	KeyType reflect.Type
+	// Err is the cause of the failed conversion
+	Err error
+}
+
+func (e *KeyConversionError) Error() string {
+	return fmt.Sprintf(`cannot get value at path [%s]: cannot convert element [%s] at path index %d to map key of type %v: %s`, e.Path, e.Element, e.Index, e.KeyType, e.Err.Error())
+}
+
+// Unwrap returns the cause of the failed conversion
+func (e *KeyConversionError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if target is ErrKeyConversion
+func (e *KeyConversionError) Is(target error) bool {
+	return target == ErrKeyConversion
+}
@@ -0,0 +1,38 @@
+package error
+
+import "fmt"
+
+// Names of the limits that a LimitExceededError may report
+const (
+	// LimitPathLength limits the number of elements of a path
+	LimitPathLength = "path length"
+	// LimitDepth limits the nesting depth of traversed values
+	LimitDepth = "depth"
+	// LimitElements limits the number of collection elements, map entries and struct fields that are visited
+	LimitElements = "elements"
+	// LimitCycle is reported if a traversal runs into a pointer cycle. It has no maximum.
+	LimitCycle = "cycle"
+)
+
+// LimitExceededError is an error that occurs when parsing a path or traversing a value exceeds a configured limit,
+// or when a traversal runs into a pointer cycle
+type LimitExceededError struct {
+	// Path is the string representation of the path
+	Path string
+	// Limit is the name of the exceeded limit, e.g. LimitDepth
+	Limit string
+	// Max is the configured maximum of the limit
+	Max int
+}
+
+func (e *LimitExceededError) Error() string {
+	if e.Limit == LimitCycle {
+		return fmt.Sprintf(`cannot traverse path [%s]: pointer cycle detected`, e.Path)
+	}
+	return fmt.Sprintf(`cannot traverse path [%s]: maximum %s of %d exceeded`, e.Path, e.Limit, e.Max)
+}
+
+// Is returns true if target is ErrLimitExceeded
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
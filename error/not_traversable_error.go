@@ -0,0 +1,25 @@
+package error
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NotTraversableError is an error that occurs when a path enters a value that is neither a map nor a struct
+type NotTraversableError struct {
+	// Path is the string representation of the path
+	Path string
+	// Index is the index of the path element that could not be entered
+	Index int
+	// Kind is the kind of the value that cannot be traversed
+	Kind reflect.Kind
+}
+
+func (e *NotTraversableError) Error() string {
+	return fmt.Sprintf(`cannot get value at path [%s]: value at path index %d is neither a map nor struct`, e.Path, e.Index)
+}
+
+// Is returns true if target is ErrNotTraversable
+func (e *NotTraversableError) Is(target error) bool {
+	return target == ErrNotTraversable
+}
@@ -0,0 +1,42 @@
+package error
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// PathNotFoundError is an error that occurs when an element of a path does not exist in the traversed value
+type PathNotFoundError struct {
+	// Path is the string representation of the path
+	Path string
+	// Index is the index of the path element that was not found
+	Index int
+	// Element is the name of the path element that was not found
+	Element string
+	// Kind is the kind of the value that does not contain the element. It is reflect.Invalid if the value is nil.
+	// For slices and arrays, the element is either not an index or out of range.
+	Kind reflect.Kind
+	// Filter is true if the element is a filter that matches no element of the value
+	Filter bool
+}
+
+func (e *PathNotFoundError) Error() string {
+	if e.Filter {
+		return fmt.Sprintf(`cannot get value at path [%s]: no element of %s matches filter [%s] at path index %d`, e.Path, e.Kind, e.Element, e.Index)
+	}
+	switch e.Kind {
+	case reflect.Map:
+		return fmt.Sprintf(`cannot get value at path [%s]: key [%s] not found in map at path index %d`, e.Path, e.Element, e.Index)
+	case reflect.Slice, reflect.Array:
+		return fmt.Sprintf(`cannot get value at path [%s]: index [%s] out of range in %s at path index %d`, e.Path, e.Element, e.Kind, e.Index)
+	case reflect.Struct:
+		return fmt.Sprintf(`cannot get value at path "%s": field "%s" not found in struct at path index %d`, e.Path, e.Element, e.Index)
+	default:
+		return fmt.Sprintf(`cannot enter field [%s] of path [%s] at index %d: value is zero or nil`, e.Element, e.Path, e.Index)
+	}
+}
+
+// Is returns true if target is ErrPathNotFound
+func (e *PathNotFoundError) Is(target error) bool {
+	return target == ErrPathNotFound
+}
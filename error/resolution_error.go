@@ -0,0 +1,21 @@
+package error
+
+import "fmt"
+
+// ResolutionError is an error that occurred while resolving the type at a single location of the target
+type ResolutionError struct {
+	// Location is the JSON Pointer to the target value whose type could not be resolved, e.g. /items/3/payload.
+	// It is empty if the failing resolver has no single target path.
+	Location string
+	// Err is the error that occurred
+	Err error
+}
+
+func (e *ResolutionError) Error() string {
+	return fmt.Sprintf("[%s]: %s", e.Location, e.Err.Error())
+}
+
+// Unwrap returns the error that occurred
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,31 @@
+package error
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TypeNotAssignableError is an error that occurs when a resolved type cannot be assigned to the target value
+type TypeNotAssignableError struct {
+	// Path is the string representation of the path to the target value
+	Path string
+	// Type is the type that was supposed to be assigned
+	Type reflect.Type
+	// TargetType is the type of the target value
+	TargetType reflect.Type
+	// Reason explains why the type cannot be assigned if the types are compatible, e.g. because the target value
+	// cannot be set. It is empty if Type is not assignable to TargetType.
+	Reason string
+}
+
+func (e *TypeNotAssignableError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf(`cannot assign type %v to value at path [%s]: %s`, e.Type, e.Path, e.Reason)
+	}
+	return fmt.Sprintf(`cannot assign type %v to value of type %v at path [%s]`, e.Type, e.TargetType, e.Path)
+}
+
+// Is returns true if target is ErrTypeNotAssignable
+func (e *TypeNotAssignableError) Is(target error) bool {
+	return target == ErrTypeNotAssignable
+}
@@ -0,0 +1,21 @@
+package error
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// UnhandledTypeError is an error that occurs when a router has no handler for the resolved type
+type UnhandledTypeError struct {
+	// Type is the resolved type that has no handler. It is nil if the polymorphic value is nil.
+	Type reflect.Type
+}
+
+func (e *UnhandledTypeError) Error() string {
+	return fmt.Sprintf("no handler registered for type %v", e.Type)
+}
+
+// Is returns true if target is ErrUnhandledType
+func (e *UnhandledTypeError) Is(target error) bool {
+	return target == ErrUnhandledType
+}
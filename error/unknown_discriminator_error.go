@@ -0,0 +1,139 @@
+package error
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxListedKeys is the maximum number of known keys listed in the message of an UnknownDiscriminatorError
+const maxListedKeys = 10
+
+// maxSuggestions is the maximum number of suggestions in the message of an UnknownDiscriminatorError
+const maxSuggestions = 3
+
+// maxValueLength is the maximum number of runes of the value that are shown in the message of an
+// UnknownDiscriminatorError. Longer values are truncated, since they may come from untrusted input.
+const maxValueLength = 64
+
+// maxSuggestedLength is the maximum number of runes of a value that suggestions are computed for. It bounds the
+// cost of the edit distance for untrusted input.
+const maxSuggestedLength = 64
+
+// UnknownDiscriminatorError is an error that occurs when a type map contains no type for a discriminator value
+type UnknownDiscriminatorError struct {
+	// Value is the discriminator value that was read from the source
+	Value any
+	// KnownKeys are the keys of the type map
+	KnownKeys []any
+}
+
+func (e *UnknownDiscriminatorError) Error() string {
+	message := fmt.Sprintf("type map does not contain any key of value [%s]", truncate(fmt.Sprintf("%+v", e.Value), maxValueLength))
+	if len(e.KnownKeys) == 0 {
+		return message
+	}
+
+	// list the accepted values
+	listed := e.KnownKeys
+	if len(listed) > maxListedKeys {
+		listed = listed[:maxListedKeys]
+	}
+	values := make([]string, len(listed))
+	for i, key := range listed {
+		values[i] = fmt.Sprintf("%+v", key)
+	}
+	message += fmt.Sprintf(". Accepted values are [%s", strings.Join(values, ", "))
+	if more := len(e.KnownKeys) - len(listed); more > 0 {
+		message += fmt.Sprintf(", ... and %d more", more)
+	}
+	message += "]"
+
+	// suggest the closest values
+	if suggestions := e.Suggestions(); len(suggestions) > 0 {
+		message += fmt.Sprintf(". Did you mean [%s]?", strings.Join(suggestions, ", "))
+	}
+	return message
+}
+
+// Suggestions returns up to three known string keys that are closest to the string Value by edit distance,
+// closest first. Keys that differ in more than a third of their characters are not suggested. Values longer than
+// 64 characters get no suggestions.
+func (e *UnknownDiscriminatorError) Suggestions() []string {
+	value, ok := e.Value.(string)
+	valueLength := utf8.RuneCountInString(value)
+	if !ok || valueLength > maxSuggestedLength {
+		return nil
+	}
+	type candidate struct {
+		key      string
+		distance int
+	}
+	var candidates []candidate
+	for _, knownKey := range e.KnownKeys {
+		key, ok := knownKey.(string)
+		if !ok {
+			continue
+		}
+		threshold := max(1, utf8.RuneCountInString(key)/3)
+		if abs(valueLength-utf8.RuneCountInString(key)) > threshold {
+			continue // the distance is at least the difference of the lengths
+		}
+		distance := levenshteinDistance(strings.ToLower(value), strings.ToLower(key))
+		if distance <= threshold {
+			candidates = append(candidates, candidate{key, distance})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+
+	suggestions := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].key)
+	}
+	return suggestions
+}
+
+// Is returns true if target is ErrUnknownDiscriminator
+func (e *UnknownDiscriminatorError) Is(target error) bool {
+	return target == ErrUnknownDiscriminator
+}
+
+// truncate returns s shortened to at most n runes, followed by an ellipsis if it was shortened
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "..."
+}
+
+// abs returns the absolute value of x
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// levenshteinDistance returns the minimum number of single rune insertions, deletions and substitutions
+// needed to change a into b.
+func levenshteinDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
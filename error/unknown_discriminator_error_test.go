@@ -0,0 +1,81 @@
+package error
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnknownDiscriminatorError_Error(t *testing.T) {
+	manyKeys := make([]any, 12)
+	for i := range manyKeys {
+		manyKeys[i] = fmt.Sprintf("type%02d", i)
+	}
+
+	type TestCase struct {
+		err     UnknownDiscriminatorError
+		message string
+	}
+	testCases := []TestCase{
+		{UnknownDiscriminatorError{"alrt", []any{"alert", "ping"}},
+			"type map does not contain any key of value [alrt]. Accepted values are [alert, ping]. Did you mean [alert]?"},
+		{UnknownDiscriminatorError{"ALERT", []any{"alert", "alerts", "ping"}},
+			"type map does not contain any key of value [ALERT]. Accepted values are [alert, alerts, ping]. Did you mean [alert, alerts]?"},
+		{UnknownDiscriminatorError{"snake", []any{"alert", "ping"}},
+			"type map does not contain any key of value [snake]. Accepted values are [alert, ping]"},
+		{UnknownDiscriminatorError{float64(3), []any{float64(1), float64(2)}},
+			"type map does not contain any key of value [3]. Accepted values are [1, 2]"},
+		{UnknownDiscriminatorError{"type1", manyKeys},
+			"type map does not contain any key of value [type1]. Accepted values are [type00, type01, type02, type03, type04, type05, type06, type07, type08, type09, ... and 2 more]. Did you mean [type01, type10, type11]?"},
+		{UnknownDiscriminatorError{"alert", nil},
+			"type map does not contain any key of value [alert]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.message, func(t *testing.T) {
+			if message := tc.err.Error(); message != tc.message {
+				t.Fatalf(`expected message to be [%s], but got [%s]`, tc.message, message)
+			}
+		})
+	}
+}
+
+func TestUnknownDiscriminatorError_LongValue(t *testing.T) {
+	keys := make([]any, 100)
+	for i := range keys {
+		keys[i] = strings.Repeat("a", 1<<20-i)
+	}
+	err := UnknownDiscriminatorError{strings.Repeat("a", 1<<20), keys}
+
+	start := time.Now()
+	message := err.Error()
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the message of a long value to be built quickly, but it took %s", elapsed)
+	} else if expected := "type map does not contain any key of value [" + strings.Repeat("a", maxValueLength) + "...]"; !strings.HasPrefix(message, expected) {
+		t.Fatalf("expected message to start with the truncated value, but got [%.100s]", message)
+	} else if suggestions := err.Suggestions(); len(suggestions) > 0 {
+		t.Fatalf("expected no suggestions for a long value, but got %d", len(suggestions))
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	type TestCase struct {
+		a, b     string
+		distance int
+	}
+	testCases := []TestCase{
+		{"", "", 0},
+		{"alert", "alert", 0},
+		{"alrt", "alert", 1},
+		{"kitten", "sitting", 3},
+		{"", "ping", 4},
+	}
+
+	for _, tc := range testCases {
+		if distance := levenshteinDistance(tc.a, tc.b); distance != tc.distance {
+			t.Errorf("expected distance between [%s] and [%s] to be %d, but got %d", tc.a, tc.b, tc.distance, distance)
+		}
+	}
+}
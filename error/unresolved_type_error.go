@@ -11,3 +11,13 @@ type UnresolvedTypeError struct {
 func (e *UnresolvedTypeError) Error() string {
 	return fmt.Sprintf("unresolved type error at [%s]: %s", e.TargetPath, e.Err.Error())
 }
+
+// Unwrap returns the reason why the type could not be resolved
+func (e *UnresolvedTypeError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if target is ErrUnresolvedType
+func (e *UnresolvedTypeError) Is(target error) bool {
+	return target == ErrUnresolvedType
+}
@@ -0,0 +1,23 @@
+package error
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// VariantMismatchError is an error that occurs when a polymorphic value is not of the expected variant type
+type VariantMismatchError struct {
+	// Expected is the expected variant type
+	Expected reflect.Type
+	// Actual is the type of the polymorphic value. It is nil if the value is nil.
+	Actual reflect.Type
+}
+
+func (e *VariantMismatchError) Error() string {
+	return fmt.Sprintf("expected variant of type %v, but got %v", e.Expected, e.Actual)
+}
+
+// Is returns true if target is ErrVariantMismatch
+func (e *VariantMismatchError) Is(target error) bool {
+	return target == ErrVariantMismatch
+}
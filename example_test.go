@@ -37,11 +37,11 @@ func ExampleUnmarshalJSON() {
 	}
 
 	// create a TypeResolver that assigns the type of the payload based on the type field
-	err, resolver := golymorph.NewPolymorphismBuilder().
+	resolver, err := golymorph.NewPolymorphismBuilder().
 		DefineTypeAt("payload").
 		UsingTypeMap(typeMap).
 		WithDiscriminatorAt("type").
-		Build()
+		BuildResolver()
 	if err != nil {
 		panic(fmt.Sprintf("error building polymorpher: %s", err))
 	}
@@ -95,11 +95,11 @@ func ExampleTypeMapPolymorphism_AssignTargetType() {
 	}
 
 	// create a polymorpher that assigns the type of the payload based on the type field
-	err, polymorpher := golymorph.NewPolymorphismBuilder().
+	polymorpher, err := golymorph.NewPolymorphismBuilder().
 		DefineTypeAt("payload").
 		UsingTypeMap(typeMap).
 		WithDiscriminatorAt("type").
-		Build()
+		BuildResolver()
 	if err != nil {
 		panic(fmt.Sprintf("error building polymorpher: %s", err))
 	}
@@ -123,3 +123,91 @@ func ExampleTypeMapPolymorphism_AssignTargetType() {
 	// event: {Timestamp:2023-11-27T22:14:09+00:00 Payload:{Type:alert Message:something is broken!}}
 	// event payload: golymorph_test.AlertPayload {Type:alert Message:something is broken!}
 }
+
+// ExampleRouter demonstrates how to dispatch JSON messages to handlers for the type of their polymorphic payload.
+func ExampleRouter() {
+
+	type Event struct {
+		Timestamp string
+		Payload   any
+	}
+
+	type AlertPayload struct {
+		Type    string
+		Message string
+	}
+
+	type PingPayload struct {
+		Type string
+		Ip   string
+	}
+
+	resolver := golymorph.Must(golymorph.NewPolymorphismBuilder().
+		DefineTypeAt("payload").
+		UsingTypeMap(golymorph.TypeMap{
+			"alert": reflect.TypeOf(AlertPayload{}),
+			"ping":  reflect.TypeOf(PingPayload{}),
+		}).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+
+	// register a handler for each payload type
+	router := golymorph.Must(golymorph.NewRouter[Event](resolver))
+	golymorph.On(router, func(event Event, alert AlertPayload) error {
+		fmt.Printf("alert at %s: %s\n", event.Timestamp, alert.Message)
+		return nil
+	})
+	golymorph.On(router, func(event Event, ping PingPayload) error {
+		fmt.Printf("ping at %s from %s\n", event.Timestamp, ping.Ip)
+		return nil
+	})
+
+	// dispatch the messages
+	messages := []string{
+		`{ "timestamp": "2023-11-27T22:14:09+00:00", "payload": { "type": "alert", "message": "something is broken!" } }`,
+		`{ "timestamp": "2023-11-27T22:15:09+00:00", "payload": { "type": "ping", "ip": "127.0.0.1" } }`,
+	}
+	for _, message := range messages {
+		if err := router.Dispatch([]byte(message)); err != nil {
+			panic(fmt.Sprintf("error dispatching message: %s", err))
+		}
+	}
+
+	// Output:
+	// alert at 2023-11-27T22:14:09+00:00: something is broken!
+	// ping at 2023-11-27T22:15:09+00:00 from 127.0.0.1
+}
+
+// ExampleFieldAs demonstrates how to decode a JSON into a new struct and access its polymorphic field type-safely.
+func ExampleFieldAs() {
+
+	type Event struct {
+		Timestamp string
+		Payload   any
+	}
+
+	type AlertPayload struct {
+		Type    string
+		Message string
+	}
+
+	resolver := golymorph.Must(golymorph.NewPolymorphismBuilder().
+		DefineTypeAt("payload").
+		UsingTypeMap(golymorph.TypeMap{"alert": reflect.TypeOf(AlertPayload{})}).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+
+	// decode the event and get its payload
+	event, err := golymorph.Unmarshal[Event](resolver, []byte(`{ "payload": { "type": "alert", "message": "something is broken!" } }`))
+	if err != nil {
+		panic(fmt.Sprintf("error unmarshalling event: %s", err))
+	}
+	alert, err := golymorph.FieldAs[AlertPayload](event.Payload)
+	if err != nil {
+		panic(fmt.Sprintf("unexpected payload: %s", err))
+	}
+	fmt.Println(alert.Message)
+
+	// Output:
+	// something is broken!
+}
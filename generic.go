@@ -0,0 +1,33 @@
+package golymorph
+
+import (
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+)
+
+// Unmarshal unmarshals the given JSON data into a new T using the given TypeResolver, see UnmarshalJSON.
+func Unmarshal[T any](resolver TypeResolver, data []byte) (T, error) {
+	var output T
+	err := UnmarshalJSON(resolver, data, &output)
+	return output, err
+}
+
+// DecodeAs decodes the given source map into a new T using the given TypeResolver, see Decode.
+func DecodeAs[T any](resolver TypeResolver, source map[string]any) (T, error) {
+	var output T
+	err := Decode(resolver, source, &output)
+	return output, err
+}
+
+// FieldAs returns the given polymorphic value as P, e.g. FieldAs[AlertPayload](event.Payload). If the value is not
+// a P, an error.VariantMismatchError is returned.
+func FieldAs[P any](value any) (P, error) {
+	variant, ok := value.(P)
+	if !ok {
+		return variant, &golimorphError.VariantMismatchError{
+			Expected: reflect.TypeOf((*P)(nil)).Elem(),
+			Actual:   reflect.TypeOf(value),
+		}
+	}
+	return variant, nil
+}
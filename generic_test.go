@@ -0,0 +1,49 @@
+package golymorph
+
+import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	for _, tc := range testCases {
+
+		// Act
+		animal, err := Unmarshal[Animal](animalPolymorphism, []byte(tc.inputJson))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("error unmarshalling animal: %s", err)
+		} else if !reflect.DeepEqual(animal, tc.output) {
+			t.Fatalf("expected animal to be %+v, but got %+v", tc.output, animal)
+		}
+	}
+}
+
+func TestFieldAs(t *testing.T) {
+
+	// Arrange
+	animal, err := Unmarshal[Animal](animalPolymorphism, []byte(testCases[0].inputJson))
+	if err != nil {
+		t.Fatalf("error unmarshalling animal: %s", err)
+	}
+
+	// Act
+	horse, horseErr := FieldAs[Horse](animal.Specifics)
+	_, duckErr := FieldAs[Duck](animal.Specifics)
+
+	// Assert
+	if horseErr != nil {
+		t.Fatalf("expected no error, but got %s", horseErr)
+	} else if horse.Shoes != 4 {
+		t.Fatalf("expected horse to have 4 shoes, but got %d", horse.Shoes)
+	}
+	var variantMismatchError *golimorphError.VariantMismatchError
+	if !errors.As(duckErr, &variantMismatchError) {
+		t.Fatalf("expected a VariantMismatchError, but got %v", duckErr)
+	} else if variantMismatchError.Actual != reflect.TypeOf(Horse{}) || variantMismatchError.Expected != reflect.TypeOf(Duck{}) {
+		t.Fatalf("expected mismatch between Duck and Horse, but got %s", variantMismatchError)
+	}
+}
@@ -0,0 +1,16 @@
+// Package golymorphtest provides test helpers for code that uses golymorph.
+package golymorphtest
+
+import (
+	"github.com/SoulKa/golymorph"
+	"testing"
+)
+
+// AssertExhaustive fails the test if the TypeMap does not cover exactly the variants of the VariantSet, i.e. if a
+// registered variant has no discriminator value or a type of the TypeMap is not a registered variant.
+func AssertExhaustive(t testing.TB, set *golymorph.VariantSet, typeMap golymorph.TypeMap) {
+	t.Helper()
+	if err := set.VerifyExhaustive(typeMap); err != nil {
+		t.Errorf("type map is not exhaustive for %v:\n%s", set.Interface, err)
+	}
+}
@@ -0,0 +1,47 @@
+package golymorphtest
+
+import (
+	"github.com/SoulKa/golymorph"
+	"reflect"
+	"testing"
+)
+
+type Shape interface {
+	isShape()
+}
+
+type Circle struct{}
+
+func (Circle) isShape() {}
+
+type Square struct{}
+
+func (Square) isShape() {}
+
+// recorder is a testing.TB that records whether the test failed
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(string, ...any) {
+	r.failed = true
+}
+
+var shapeVariants = golymorph.Must(golymorph.NewVariantSet[Shape](reflect.TypeOf(Circle{}), reflect.TypeOf(Square{})))
+
+func TestAssertExhaustive(t *testing.T) {
+	AssertExhaustive(t, shapeVariants, golymorph.TypeMap{
+		"circle": reflect.TypeOf(Circle{}),
+		"square": reflect.TypeOf(Square{}),
+	})
+
+	// a missing variant must fail the test
+	r := recorder{TB: t}
+	AssertExhaustive(&r, shapeVariants, golymorph.TypeMap{"circle": reflect.TypeOf(Circle{})})
+	if !r.failed {
+		t.Fatalf("expected AssertExhaustive to fail for a missing variant")
+	}
+}
@@ -0,0 +1,220 @@
+package golymorph
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+)
+
+// jsonPeekNode is a node of a tree of the JSON object keys that are needed to resolve a polymorphism.
+// If leaf is true, the complete value at the node is needed.
+type jsonPeekNode struct {
+	leaf     bool
+	children map[string]*jsonPeekNode
+}
+
+// add adds the given path to the tree. It returns false if the path contains elements that are not identifiers.
+func (n *jsonPeekNode) add(path objectpath.ObjectPath) bool {
+	for _, element := range path.Elements() {
+		if element.Type() != objectpath.ElementTypeIdentifier {
+			return false
+		}
+		if n.children == nil {
+			n.children = make(map[string]*jsonPeekNode)
+		}
+		child, ok := n.children[element.Name()]
+		if !ok {
+			child = &jsonPeekNode{}
+			n.children[element.Name()] = child
+		}
+		n = child
+	}
+	n.leaf = true
+	return true
+}
+
+// peek decodes the parts of raw that are described by the node into a sparse copy of the JSON document.
+// Values that are not JSON objects are decoded completely, so that resolving a polymorphism on the sparse
+// copy fails in the same way as on the complete document.
+func (n *jsonPeekNode) peek(raw json.RawMessage) (any, error) {
+	if n.leaf || !isJSONObject(raw) {
+		var value any
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	}
+	sparse := make(map[string]any, len(n.children))
+	err := scanJSONObject(raw, func(key string, rawChild json.RawMessage) error {
+		child, ok := n.children[key]
+		if !ok {
+			return nil
+		}
+		value, err := child.peek(rawChild)
+		sparse[key] = value
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sparse, nil
+}
+
+// scanJSONObject calls fn for each member of the JSON object in raw without decoding the member values.
+// The raw JSON must be valid.
+func scanJSONObject(raw json.RawMessage, fn func(key string, value json.RawMessage) error) error {
+	i := skipJSONWhitespace(raw, 0) + 1 // skip opening brace
+	for {
+		i = skipJSONWhitespace(raw, i)
+		if i >= len(raw) || raw[i] != '"' {
+			return nil // closing brace or invalid JSON
+		}
+
+		// read key
+		keyEnd := skipJSONString(raw, i)
+		key := string(raw[i+1 : keyEnd-1])
+		if bytes.IndexByte(raw[i:keyEnd], '\\') >= 0 {
+			if err := json.Unmarshal(raw[i:keyEnd], &key); err != nil {
+				return err
+			}
+		}
+
+		// read value
+		i = skipJSONWhitespace(raw, keyEnd)
+		if i >= len(raw) || raw[i] != ':' {
+			return nil
+		}
+		valueStart := skipJSONWhitespace(raw, i+1)
+		valueEnd := skipJSONValue(raw, valueStart)
+		if err := fn(key, raw[valueStart:valueEnd]); err != nil {
+			return err
+		}
+
+		// skip comma
+		i = skipJSONWhitespace(raw, valueEnd)
+		if i >= len(raw) || raw[i] != ',' {
+			return nil
+		}
+		i++
+	}
+}
+
+// skipJSONWhitespace returns the index of the first non-whitespace character in raw starting at i.
+func skipJSONWhitespace(raw []byte, i int) int {
+	for i < len(raw) && (raw[i] == ' ' || raw[i] == '\t' || raw[i] == '\r' || raw[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+// skipJSONString returns the index after the closing quote of the JSON string starting at i.
+func skipJSONString(raw []byte, i int) int {
+	for i++; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(raw)
+}
+
+// skipJSONValue returns the index after the end of the JSON value starting at i.
+func skipJSONValue(raw []byte, i int) int {
+	depth := 0
+	for i < len(raw) {
+		switch raw[i] {
+		case '"':
+			i = skipJSONString(raw, i)
+			if depth == 0 {
+				return i
+			}
+			continue
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				return i
+			}
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case ',', ' ', '\t', '\r', '\n':
+			if depth == 0 {
+				return i
+			}
+		}
+		i++
+	}
+	return i
+}
+
+// isJSONObject returns true if raw starts with an opening brace.
+func isJSONObject(raw json.RawMessage) bool {
+	raw = bytes.TrimLeft(raw, " \t\r\n")
+	return len(raw) > 0 && raw[0] == '{'
+}
+
+// UnmarshalJSONFast unmarshals the given JSON data into the given output object using the given TypeResolver.
+// Contrary to UnmarshalJSON, it does not decode the data into an intermediate map. Instead, only the values that
+// are needed to resolve the polymorphism are peeked, and the data is unmarshalled with encoding/json directly into
+// the resolved type. Hence, the json struct tags of the output are used instead of the mapstructure tags.
+// Resolvers other than TypeMapPolymorphism and RulePolymorphism fall back to UnmarshalJSON.
+func UnmarshalJSONFast(resolver TypeResolver, data []byte, output any) error {
+
+	// collect the paths needed for resolving the polymorphism
+	var root jsonPeekNode
+	var targetPath objectpath.ObjectPath
+	supported := true
+	switch p := resolver.(type) {
+	case *TypeMapPolymorphism:
+		targetPath = p.TargetPath
+		supported = root.add(p.DiscriminatorPath)
+	case *RulePolymorphism:
+		targetPath = p.TargetPath
+		for _, rule := range p.Rules {
+			supported = supported && root.add(rule.ValuePath)
+		}
+	default:
+		supported = false
+	}
+	if !supported || root.leaf {
+		return UnmarshalJSON(resolver, data, output)
+	}
+
+	// peek the values needed for resolution
+	var source map[string]any
+	if !isJSONObject(data) || !json.Valid(data) {
+		return json.Unmarshal(data, &source) // fails just like UnmarshalJSON
+	} else if sparse, err := root.peek(data); err != nil {
+		return err
+	} else {
+		source = sparse.(map[string]any)
+	}
+
+	// resolve polymorphism
+	if err := resolver.AssignTargetType(&source, output); err != nil {
+		return err
+	}
+
+	// let encoding/json decode into a pointer to the resolved type
+	var target reflect.Value
+	if err := objectpath.GetValueAtPath(output, targetPath, &target); err != nil {
+		return err
+	}
+	if target.Kind() != reflect.Interface || target.IsNil() {
+		return json.Unmarshal(data, output)
+	}
+	pointer := reflect.New(target.Elem().Type())
+	target.Set(pointer)
+	if err := json.Unmarshal(data, output); err != nil {
+		return err
+	}
+
+	// replace the pointer by the resolved value itself
+	if !target.IsNil() && target.Elem().Kind() == reflect.Ptr && target.Elem().Pointer() == pointer.Pointer() {
+		target.Set(pointer.Elem())
+	}
+	return nil
+}
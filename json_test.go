@@ -0,0 +1,74 @@
+package golymorph
+
+import (
+	"reflect"
+	"testing"
+)
+
+var animalPolymorphism = Must(NewPolymorphismBuilder().
+	DefineTypeAt("specifics").
+	UsingTypeMap(animalTypeMap).
+	WithDiscriminatorAt("type").
+	BuildResolver())
+
+func TestUnmarshalJSONFast(t *testing.T) {
+	horseRule := Must(NewRuleBuilder().
+		WhenValueAt("/specifics/type").
+		IsEqualTo("horse").
+		ThenAssignType(reflect.TypeOf(Horse{})).
+		BuildRule())
+	duckRule := Must(NewRuleBuilder().
+		WhenValueAt("/specifics/type").
+		IsEqualTo("duck").
+		ThenAssignType(reflect.TypeOf(Duck{})).
+		BuildRule())
+	resolvers := map[string]TypeResolver{
+		"TypeMapPolymorphism": animalPolymorphism,
+		"RulePolymorphism": Must(NewPolymorphismBuilder().
+			DefineTypeAt("specifics").
+			UsingRule(horseRule).
+			UsingRule(duckRule).
+			BuildResolver()),
+	}
+
+	for name, resolver := range resolvers {
+		for _, tc := range testCases {
+			t.Run(name+"_"+tc.output.Name, func(t *testing.T) {
+
+				// Act
+				var actualAnimal Animal
+				if err := UnmarshalJSONFast(resolver, []byte(tc.inputJson), &actualAnimal); err != nil {
+					t.Fatalf("error unmarshalling animal: %s", err)
+				}
+
+				// Assert
+				if !reflect.DeepEqual(actualAnimal, tc.output) {
+					t.Fatalf("expected animal to be %+v, but got %+v", tc.output, actualAnimal)
+				}
+			})
+		}
+	}
+}
+
+func TestUnmarshalJSONFastWithError(t *testing.T) {
+	testCases := []string{
+		`[]`,
+		`{ "name": "snakey", "specifics": { "type": "snake" }`,
+		`{ "name": "snakey", "specifics": { "type": "snake" } }`,
+		`{ "name": "snakey", "specifics": "snake" }`,
+		`{ "name": "snakey" }`,
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			var expected, actual Animal
+			expectedErr := UnmarshalJSON(animalPolymorphism, []byte(tc), &expected)
+			actualErr := UnmarshalJSONFast(animalPolymorphism, []byte(tc), &actual)
+			if expectedErr == nil || actualErr == nil {
+				t.Fatalf("expected errors, but got [%v] and [%v]", expectedErr, actualErr)
+			} else if expectedErr.Error() != actualErr.Error() {
+				t.Fatalf("expected error to be [%s], but got [%s]", expectedErr, actualErr)
+			}
+		})
+	}
+}
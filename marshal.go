@@ -0,0 +1,79 @@
+package golymorph
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+	"strings"
+)
+
+// MarshalJSON marshals the given input into JSON and sets the discriminator values of its polymorphic fields, so
+// that UnmarshalJSON resolves the same types again. Keys along the discriminator paths are spelled like the paths.
+// The resolver must be a TypeMapPolymorphism or a ResolverGroup of them. The input must be a pointer.
+func MarshalJSON(resolver TypeResolver, input any) ([]byte, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if err := setDiscriminators(resolver, input, document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+// setDiscriminators sets the discriminator values of the polymorphic fields of input in the JSON document.
+func setDiscriminators(resolver TypeResolver, input any, document map[string]any) error {
+	switch r := resolver.(type) {
+	case *ResolverGroup:
+		for _, resolver := range r.Resolvers {
+			if err := setDiscriminators(resolver, input, document); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *TypeMapPolymorphism:
+		var variant reflect.Value
+		if err := objectpath.GetValueAtPath(input, r.TargetPath, &variant); err != nil {
+			return err
+		} else if !variant.IsValid() || (variant.Kind() == reflect.Interface && variant.IsNil()) {
+			return nil // nothing to discriminate
+		}
+		discriminator, err := r.DiscriminatorOf(variant.Interface())
+		if err != nil {
+			return err
+		}
+		return setJSONValueAtPath(document, r.DiscriminatorPath, discriminator)
+	default:
+		return fmt.Errorf("cannot marshal with resolver of type %T", resolver)
+	}
+}
+
+// setJSONValueAtPath sets the value at the given path of a decoded JSON document. Keys are matched exactly first
+// and case-insensitively second, just like encoding/json matches struct fields. Keys matched case-insensitively are
+// renamed to the spelling of the path, so that the path can be resolved again. Missing objects are created.
+func setJSONValueAtPath(document map[string]any, path objectpath.ObjectPath, value any) error {
+	current := document
+	for _, element := range path.Elements() {
+		key := element.Name()
+		if _, ok := current[key]; !ok {
+			for other, otherValue := range current {
+				if strings.EqualFold(other, key) {
+					delete(current, other)
+					current[key] = otherValue
+					break
+				}
+			}
+		}
+		child, ok := current[key].(map[string]any)
+		if !ok {
+			break
+		}
+		current = child
+	}
+	return objectpath.SetValueAtPath(&document, path, value)
+}
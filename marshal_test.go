@@ -0,0 +1,112 @@
+package golymorph
+
+import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"testing"
+)
+
+type Cow struct {
+	Spots int
+}
+
+func (Cow) Kind() any {
+	return "cow"
+}
+
+type Sheep struct {
+	Wool int
+}
+
+func (*Sheep) Kind() any {
+	return "sheep"
+}
+
+func TestMarshalJSON(t *testing.T) {
+	for _, tc := range testCases {
+
+		// Act
+		data, err := MarshalJSON(animalPolymorphism, &tc.output)
+		if err != nil {
+			t.Fatalf("error marshalling animal: %s", err)
+		}
+		animal, err := Unmarshal[Animal](animalPolymorphism, data)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("error unmarshalling animal %s: %s", data, err)
+		} else if !reflect.DeepEqual(animal, tc.output) {
+			t.Fatalf("expected animal to be %+v, but got %+v", tc.output, animal)
+		}
+	}
+}
+
+func TestMarshalJSONWithDiscriminatorMethod(t *testing.T) {
+
+	// Arrange
+	typeMap := Must(NewTypeMapFromTypes(MethodNaming("Kind"), reflect.TypeOf(Cow{}), reflect.TypeOf(Sheep{})))
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("specifics").
+		UsingTypeMap(typeMap).
+		WithDiscriminatorAt("kind").
+		UsingDiscriminatorMethod("Kind").
+		BuildResolver())
+	expected := Animal{"sheepy", Sheep{3}}
+
+	// Act
+	data, err := MarshalJSON(resolver, &expected)
+	if err != nil {
+		t.Fatalf("error marshalling animal: %s", err)
+	}
+	animal, err := Unmarshal[Animal](resolver, data)
+
+	// Assert
+	if !reflect.DeepEqual(typeMap, TypeMap{"cow": reflect.TypeOf(Cow{}), "sheep": reflect.TypeOf(Sheep{})}) {
+		t.Fatalf("expected type map to be keyed by the Kind methods, but got %v", typeMap)
+	} else if err != nil {
+		t.Fatalf("error unmarshalling animal %s: %s", data, err)
+	} else if !reflect.DeepEqual(animal, expected) {
+		t.Fatalf("expected animal to be %+v, but got %+v", expected, animal)
+	}
+}
+
+type Goat struct{}
+
+func (Goat) Kind() any {
+	return "goat"
+}
+
+func TestMarshalJSONWithDiscriminatorMethodAndError(t *testing.T) {
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("specifics").
+		UsingTypeMap(TypeMap{"cow": reflect.TypeOf(Cow{}), "goat": reflect.TypeOf(Sheep{})}).
+		WithDiscriminatorAt("kind").
+		UsingDiscriminatorMethod("Kind").
+		BuildResolver())
+
+	if _, err := MarshalJSON(resolver, &Animal{"sheepy", Sheep{3}}); !errors.Is(err, golimorphError.ErrUnknownDiscriminator) {
+		t.Errorf("expected an UnknownDiscriminatorError for a method value outside of the type map, but got %v", err)
+	}
+	if _, err := MarshalJSON(resolver, &Animal{"goaty", Goat{}}); !errors.Is(err, golimorphError.ErrVariantMismatch) {
+		t.Errorf("expected a VariantMismatchError for a method value of another type, but got %v", err)
+	}
+}
+
+func TestMethodNamingWithPointerVariant(t *testing.T) {
+	typeMap, err := NewTypeMapFromTypes(MethodNaming("Kind"), reflect.TypeOf(&Cow{}), reflect.TypeOf(&Sheep{}))
+	if err != nil {
+		t.Fatalf("error creating type map: %s", err)
+	} else if expected := (TypeMap{"cow": reflect.TypeOf(&Cow{}), "sheep": reflect.TypeOf(&Sheep{})}); !reflect.DeepEqual(typeMap, expected) {
+		t.Fatalf("expected type map to be %v, but got %v", expected, typeMap)
+	}
+}
+
+func TestMethodNamingWithError(t *testing.T) {
+	if _, err := MethodNaming("Kind")(reflect.TypeOf(Horse{})); err == nil || err.Error() != "type golymorph.Horse has no method Kind" {
+		t.Fatalf("expected error for missing method, but got %v", err)
+	}
+	if _, err := MethodNaming("String")(reflect.TypeOf(reflect.Int)); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	}
+}
@@ -0,0 +1,10 @@
+package golymorph
+
+// Must returns value if err is nil and panics otherwise. It is intended for package level initialization of
+// resolvers and rules, e.g. golymorph.Must(golymorph.NewRuleBuilder()...BuildRule()).
+func Must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
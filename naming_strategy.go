@@ -0,0 +1,186 @@
+package golymorph
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy derives the discriminator value of a type, e.g. "alert_payload" for AlertPayload.
+type NamingStrategy func(t reflect.Type) (any, error)
+
+// Discriminator is implemented by types that define their own discriminator value, see DiscriminatorMethodNaming.
+type Discriminator interface {
+	// Discriminator returns the discriminator value of the type
+	Discriminator() string
+}
+
+// typeName returns the name of t. Pointers are dereferenced.
+func typeName(t reflect.Type) (string, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return "", fmt.Errorf("cannot derive discriminator value of unnamed type %v", t)
+	}
+	return t.Name(), nil
+}
+
+// splitWords splits a Go identifier at its word boundaries, e.g. HTTPRequestPayload into [HTTP Request Payload].
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		lowerToUpper := unicode.IsLower(runes[i-1]) && unicode.IsUpper(runes[i])
+		acronymEnd := unicode.IsUpper(runes[i-1]) && unicode.IsUpper(runes[i]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		digitToUpper := unicode.IsDigit(runes[i-1]) && unicode.IsUpper(runes[i])
+		if lowerToUpper || acronymEnd || digitToUpper || runes[i] == '_' {
+			if word := strings.Trim(string(runes[start:i]), "_"); word != "" {
+				words = append(words, word)
+			}
+			start = i
+		}
+	}
+	if word := strings.Trim(string(runes[start:]), "_"); word != "" {
+		words = append(words, word)
+	}
+	return words
+}
+
+// NameStrategy derives the discriminator value from a type name. Unlike a NamingStrategy, it only depends on the
+// name, so it can be composed with strategies that change the name, see StripSuffix.
+type NameStrategy func(name string) any
+
+// joinedLowerCase returns a NameStrategy that joins the lower case words of the name with separator.
+func joinedLowerCase(separator string) NameStrategy {
+	return func(name string) any {
+		return strings.ToLower(strings.Join(splitWords(name), separator))
+	}
+}
+
+// SnakeCase converts a type name to snake_case, e.g. "alert_payload" for AlertPayload.
+var SnakeCase = joinedLowerCase("_")
+
+// KebabCase converts a type name to kebab-case, e.g. "alert-payload" for AlertPayload.
+var KebabCase = joinedLowerCase("-")
+
+// FromName returns a NamingStrategy that applies strategy to the name of the type. Pointers are dereferenced.
+func FromName(strategy NameStrategy) NamingStrategy {
+	return func(t reflect.Type) (any, error) {
+		name, err := typeName(t)
+		if err != nil {
+			return nil, err
+		}
+		return strategy(name), nil
+	}
+}
+
+// SnakeCaseNaming derives the discriminator value from the snake_case type name, e.g. "alert_payload" for AlertPayload.
+var SnakeCaseNaming = FromName(SnakeCase)
+
+// KebabCaseNaming derives the discriminator value from the kebab-case type name, e.g. "alert-payload" for AlertPayload.
+var KebabCaseNaming = FromName(KebabCase)
+
+// TypeNameNaming uses the plain type name as discriminator value, e.g. "AlertPayload".
+func TypeNameNaming(t reflect.Type) (any, error) {
+	name, err := typeName(t)
+	return name, err
+}
+
+// FullyQualifiedNaming uses the package path and type name as discriminator value, e.g. "github.com/foo/events.AlertPayload".
+func FullyQualifiedNaming(t reflect.Type) (any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return nil, fmt.Errorf("cannot derive discriminator value of unnamed type %v", t)
+	}
+	return t.PkgPath() + "." + t.Name(), nil
+}
+
+// zeroVariant returns the zero value of t to call discriminator methods on. For pointer types, it is a pointer to the
+// zero value of the element type instead of a nil pointer, so that methods with value receivers can be called.
+func zeroVariant(t reflect.Type) reflect.Value {
+	if t.Kind() == reflect.Ptr {
+		return reflect.New(t.Elem())
+	}
+	return reflect.New(t).Elem()
+}
+
+// DiscriminatorMethodNaming uses the result of the Discriminator method of the type's zero value as discriminator value.
+// For pointer types, the method is called on a pointer to the zero value of the element type.
+func DiscriminatorMethodNaming(t reflect.Type) (any, error) {
+	discriminator, ok := zeroVariant(t).Interface().(Discriminator)
+	if !ok {
+		return nil, fmt.Errorf("type %v does not implement golymorph.Discriminator", t)
+	}
+	return discriminator.Discriminator(), nil
+}
+
+// MethodNaming returns a NamingStrategy that uses the result of the given method of the type's zero value as
+// discriminator value, e.g. MethodNaming("Kind") for types implementing interface{ Kind() string }. The method must
+// not take arguments and return a single value. Methods with pointer receivers and pointer types are supported.
+func MethodNaming(methodName string) NamingStrategy {
+	return func(t reflect.Type) (any, error) {
+		return callDiscriminatorMethod(zeroVariant(t), methodName)
+	}
+}
+
+// callDiscriminatorMethod calls the method with the given name on value and returns its single result. If value has
+// no such method, it is called on a pointer to a copy of value.
+func callDiscriminatorMethod(value reflect.Value, methodName string) (any, error) {
+	method := value.MethodByName(methodName)
+	if !method.IsValid() && value.Kind() != reflect.Ptr {
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(value)
+		method = pointer.MethodByName(methodName)
+	}
+	if !method.IsValid() {
+		return nil, fmt.Errorf("type %v has no method %s", value.Type(), methodName)
+	} else if method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil, fmt.Errorf("method %s of type %v must not take arguments and return a single value", methodName, value.Type())
+	}
+	return method.Call(nil)[0].Interface(), nil
+}
+
+// StripSuffix returns a NamingStrategy that removes suffix from the type name before passing it to strategy,
+// e.g. StripSuffix("Payload", SnakeCase) derives "alert" for AlertPayload.
+func StripSuffix(suffix string, strategy NameStrategy) NamingStrategy {
+	return func(t reflect.Type) (any, error) {
+		name, err := typeName(t)
+		if err != nil {
+			return nil, err
+		}
+		trimmed := strings.TrimSuffix(name, suffix)
+		if trimmed == "" {
+			return nil, fmt.Errorf("cannot strip suffix %s from type name %s", suffix, name)
+		}
+		return strategy(trimmed), nil
+	}
+}
+
+// NewTypeMapFromTypes creates a TypeMap with the discriminator value of each type derived by strategy. It returns an
+// error if the strategy fails for a type or two types have the same discriminator value.
+func NewTypeMapFromTypes(strategy NamingStrategy, types ...reflect.Type) (TypeMap, error) {
+	typeMap := make(TypeMap, len(types))
+	var errs []error
+	for _, t := range types {
+		key, err := strategy(t)
+		if err != nil {
+			errs = append(errs, err)
+		} else if !isComparable(key) {
+			errs = append(errs, fmt.Errorf("discriminator value [%+v] of type %v cannot be a key of a type map", key, t))
+		} else if other, ok := typeMap[key]; ok {
+			errs = append(errs, fmt.Errorf("discriminator value [%+v] of type %v collides with type %v", key, t, other))
+		} else {
+			typeMap[key] = t
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return typeMap, nil
+}
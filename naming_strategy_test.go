@@ -0,0 +1,104 @@
+package golymorph
+
+import (
+	"reflect"
+	"testing"
+)
+
+type AlertPayload struct{}
+
+type HTTPRequestPayload struct{}
+
+type V2Payload struct{}
+
+type KindPayload struct{}
+
+func (KindPayload) Discriminator() string {
+	return "kind"
+}
+
+func TestNamingStrategies(t *testing.T) {
+	type TestCase struct {
+		name     string
+		strategy NamingStrategy
+		t        reflect.Type
+		expected any
+	}
+	testCases := []TestCase{
+		{"snake case", SnakeCaseNaming, reflect.TypeOf(AlertPayload{}), "alert_payload"},
+		{"snake case with acronym", SnakeCaseNaming, reflect.TypeOf(HTTPRequestPayload{}), "http_request_payload"},
+		{"snake case with digit", SnakeCaseNaming, reflect.TypeOf(V2Payload{}), "v2_payload"},
+		{"kebab case of pointer", KebabCaseNaming, reflect.TypeOf(&AlertPayload{}), "alert-payload"},
+		{"type name", TypeNameNaming, reflect.TypeOf(AlertPayload{}), "AlertPayload"},
+		{"strip suffix", StripSuffix("Payload", SnakeCase), reflect.TypeOf(&HTTPRequestPayload{}), "http_request"},
+		{"fully qualified", FullyQualifiedNaming, reflect.TypeOf(AlertPayload{}), "github.com/SoulKa/golymorph.AlertPayload"},
+		{"discriminator method", DiscriminatorMethodNaming, reflect.TypeOf(KindPayload{}), "kind"},
+		{"discriminator method of pointer", DiscriminatorMethodNaming, reflect.TypeOf(&KindPayload{}), "kind"},
+		{"strip suffix with kebab case", StripSuffix("Payload", KebabCase), reflect.TypeOf(HTTPRequestPayload{}), "http-request"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual, err := tc.strategy(tc.t); err != nil {
+				t.Fatalf("error deriving discriminator value: %s", err)
+			} else if actual != tc.expected {
+				t.Fatalf("expected discriminator value to be [%v], but got [%v]", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewTypeMapFromTypes(t *testing.T) {
+
+	// Act
+	typeMap, err := NewTypeMapFromTypes(StripSuffix("Payload", SnakeCase), reflect.TypeOf(AlertPayload{}), reflect.TypeOf(HTTPRequestPayload{}))
+
+	// Assert
+	expected := TypeMap{"alert": reflect.TypeOf(AlertPayload{}), "http_request": reflect.TypeOf(HTTPRequestPayload{})}
+	if err != nil {
+		t.Fatalf("error creating type map: %s", err)
+	} else if !reflect.DeepEqual(typeMap, expected) {
+		t.Fatalf("expected type map to be %v, but got %v", expected, typeMap)
+	}
+}
+
+func TestNewTypeMapFromTypesWithIncomparableKey(t *testing.T) {
+	strategy := func(t reflect.Type) (any, error) { return []string{t.Name()}, nil }
+	if _, err := NewTypeMapFromTypes(strategy, reflect.TypeOf(AlertPayload{})); err == nil {
+		t.Fatalf("expected an error for an incomparable discriminator value, but got none")
+	}
+}
+
+func TestNewTypeMapFromTypesWithPointerVariant(t *testing.T) {
+	typeMap, err := NewTypeMapFromTypes(DiscriminatorMethodNaming, reflect.TypeOf(&KindPayload{}))
+	if err != nil {
+		t.Fatalf("error creating type map: %s", err)
+	} else if expected := (TypeMap{"kind": reflect.TypeOf(&KindPayload{})}); !reflect.DeepEqual(typeMap, expected) {
+		t.Fatalf("expected type map to be %v, but got %v", expected, typeMap)
+	}
+}
+
+func TestNewTypeMapFromTypesWithError(t *testing.T) {
+	type TestCase struct {
+		name     string
+		strategy NamingStrategy
+		types    []reflect.Type
+		error    string
+	}
+	testCases := []TestCase{
+		{"collision", StripSuffix("Payload", SnakeCase), []reflect.Type{reflect.TypeOf(AlertPayload{}), reflect.TypeOf(&AlertPayload{})},
+			"discriminator value [alert] of type *golymorph.AlertPayload collides with type golymorph.AlertPayload"},
+		{"unnamed type", SnakeCaseNaming, []reflect.Type{reflect.TypeOf(struct{}{})},
+			"cannot derive discriminator value of unnamed type struct {}"},
+		{"missing method", DiscriminatorMethodNaming, []reflect.Type{reflect.TypeOf(AlertPayload{})},
+			"type golymorph.AlertPayload does not implement golymorph.Discriminator"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewTypeMapFromTypes(tc.strategy, tc.types...); err == nil || err.Error() != tc.error {
+				t.Fatalf("expected error to be [%s], but got [%v]", tc.error, err)
+			}
+		})
+	}
+}
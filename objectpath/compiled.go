@@ -0,0 +1,111 @@
+package objectpath
+
+import (
+	"fmt"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+)
+
+// compiledStep is a single step of a CompiledPath. If fieldIndex is nil, the step is resolved dynamically.
+type compiledStep struct {
+	fieldIndex []int
+}
+
+// CompiledPath is an ObjectPath bound to a concrete root type. The field index chains of all struct fields that
+// can be determined from the root type are computed once, so that getting a value does not need to search for
+// fields by name. Hops into maps and interfaces fall back to the dynamic lookup of GetValueAtPath.
+type CompiledPath struct {
+	path     ObjectPath
+	rootType reflect.Type
+	steps    []compiledStep
+}
+
+// Compile binds the given path to rootType, the type that the source passed to CompiledPath.GetValue points to.
+// The path must not contain self or upwards references, i.e. it has to be normalized if it is absolute.
+func Compile(path ObjectPath, rootType reflect.Type) (*CompiledPath, error) {
+	compiled := &CompiledPath{path, rootType, make([]compiledStep, len(path.elements))}
+	currentType := rootType
+	for i, element := range path.elements {
+		if element.elementType != ElementTypeIdentifier {
+			return nil, fmt.Errorf(`cannot compile path [%s]: element at path index %d is not an identifier`, path.String(), i)
+		}
+
+		// from here on, the types are only known at runtime
+		if currentType == nil {
+			continue
+		}
+
+		// Dereference pointer
+		if currentType.Kind() == reflect.Ptr {
+			currentType = currentType.Elem()
+		}
+
+		switch currentType.Kind() {
+		case reflect.Struct:
+			index, ok := lookupField(currentType, element.name)
+			if !ok {
+				return nil, fmt.Errorf(`cannot compile path "%s": field "%s" not found in struct at path index %d`, path.String(), element.name, i)
+			}
+			compiled.steps[i].fieldIndex = index
+			currentType = currentType.FieldByIndex(index).Type
+		case reflect.Map, reflect.Interface:
+			currentType = nil
+		default:
+			return nil, fmt.Errorf(`cannot compile path [%s]: value at path index %d is neither a map nor struct`, path.String(), i)
+		}
+	}
+	return compiled, nil
+}
+
+// Path returns the ObjectPath the CompiledPath was compiled from.
+func (p *CompiledPath) Path() ObjectPath {
+	return p.path
+}
+
+// RootType returns the type the CompiledPath is bound to.
+func (p *CompiledPath) RootType() reflect.Type {
+	return p.rootType
+}
+
+// GetValue returns the value at the compiled path in source like GetValueAtPath does. The source must be a
+// pointer to a value of the root type of the CompiledPath.
+func (p *CompiledPath) GetValue(source any, out *reflect.Value) error {
+	value := reflect.ValueOf(source)
+	if value.Kind() != reflect.Ptr {
+		return fmt.Errorf(`cannot get value at path [%s]: source is not a pointer`, p.path.String())
+	} else if value.Type().Elem() != p.rootType {
+		return fmt.Errorf(`cannot get value at path [%s]: source is of type %s, but the path is compiled for %s`, p.path.String(), value.Type().Elem(), p.rootType)
+	}
+	value = value.Elem()
+
+	// Iterate over compiled steps
+	for i, step := range p.steps {
+		var err error
+		if step.fieldIndex == nil {
+			value, err = enterElement(value, p.path, i, DefaultLimits)
+		} else {
+			if value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					return &golimorphError.PathNotFoundError{Path: p.path.String(), Index: i, Element: p.path.elements[i].name, Kind: reflect.Invalid}
+				}
+				value = value.Elem()
+			}
+			value, err = value.FieldByIndexErr(step.fieldIndex)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	*out = value
+	return nil
+}
+
+// AssignType assigns the given reflect.Type to the value at the compiled path in source like AssignTypeAtPath does.
+func (p *CompiledPath) AssignType(source any, newType reflect.Type) error {
+	var value reflect.Value
+	if err := p.GetValue(source, &value); err != nil {
+		return err
+	}
+
+	return assignType(value, p.path, newType)
+}
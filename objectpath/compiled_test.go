@@ -0,0 +1,113 @@
+package objectpath
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type Zoo struct {
+	Keeper  *Animal
+	Animals map[string]any
+}
+
+func TestCompiledPath_GetValue(t *testing.T) {
+	type TestCase struct {
+		inputPath string
+		output    any
+	}
+	testCases := []TestCase{
+		{"keeper/name", "bob"},
+		{"animals/horse/shoes", 4},
+		{"keeper/specifics/feathers", 1000},
+	}
+	zoo := Zoo{
+		Keeper:  &Animal{Name: "bob", Specifics: map[string]any{"feathers": 1000}},
+		Animals: map[string]any{"horse": map[string]any{"shoes": 4}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.inputPath, func(t *testing.T) {
+
+			// Arrange
+			compiled, err := Compile(*MustParse(tc.inputPath), reflect.TypeOf(zoo))
+			if err != nil {
+				t.Fatalf("error compiling path %s: %s", tc.inputPath, err)
+			}
+
+			// Act
+			var outVal reflect.Value
+			if err := compiled.GetValue(&zoo, &outVal); err != nil {
+				t.Fatalf("error getting value at path %s: %s", tc.inputPath, err)
+			}
+
+			// Assert
+			if outVal.Interface() != tc.output {
+				t.Fatalf("expected output to be %v, but got %v", tc.output, outVal)
+			}
+		})
+	}
+}
+
+func TestCompiledPath_AssignType(t *testing.T) {
+
+	// Arrange
+	compiled, err := Compile(*MustParse("specifics"), reflect.TypeOf(Animal{}))
+	if err != nil {
+		t.Fatalf("error compiling path: %s", err)
+	}
+
+	// Act
+	var wg sync.WaitGroup
+	animals := make([]Animal, 16)
+	for i := range animals {
+		wg.Add(1)
+		go func(animal *Animal) {
+			defer wg.Done()
+			if err := compiled.AssignType(animal, reflect.TypeOf(Horse{})); err != nil {
+				t.Errorf("error assigning type: %s", err)
+			}
+		}(&animals[i])
+	}
+	wg.Wait()
+
+	// Assert
+	for _, animal := range animals {
+		if reflect.TypeOf(animal.Specifics) != reflect.TypeOf(Horse{}) {
+			t.Fatalf("expected specifics to be of type Horse, but got %T", animal.Specifics)
+		}
+	}
+}
+
+func TestCompileWithError(t *testing.T) {
+	type TestCase struct {
+		inputPath string
+		error     string
+	}
+	testCases := []TestCase{
+		{"keeper/age", `cannot compile path "keeper/age": field "age" not found in struct at path index 1`},
+		{"keeper/name/length", `cannot compile path [keeper/name/length]: value at path index 2 is neither a map nor struct`},
+		{"../keeper", `cannot compile path [../keeper]: element at path index 0 is not an identifier`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.inputPath, func(t *testing.T) {
+			if _, err := Compile(*MustParse(tc.inputPath), reflect.TypeOf(Zoo{})); err == nil {
+				t.Fatalf("expected error, but got none")
+			} else if err.Error() != tc.error {
+				t.Fatalf(`expected error to be [%s], but got [%s]`, tc.error, err)
+			}
+		})
+	}
+}
+
+func TestCompiledPath_GetValueWithWrongRootType(t *testing.T) {
+	compiled, err := Compile(*MustParse("name"), reflect.TypeOf(Animal{}))
+	if err != nil {
+		t.Fatalf("error compiling path: %s", err)
+	}
+	var outVal reflect.Value
+	if err := compiled.GetValue(&Zoo{}, &outVal); err == nil {
+		t.Fatalf("expected error, but got none")
+	}
+}
@@ -0,0 +1,111 @@
+package objectpath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Of creates a new ObjectPath from the given elements without parsing them, e.g. Of("items", 0, "type"). Strings
+// and all other values are identifiers, integers are formatted in base 10, and Element values are used as they are.
+// If the first element is ElementRoot, the path is absolute, otherwise it is relative.
+func Of(elements ...any) *ObjectPath {
+	path := &ObjectPath{Elements{}, false}
+	if len(elements) > 0 && elements[0] == ElementRoot {
+		path.isAbsolute = true
+		elements = elements[1:]
+	}
+	return path.Child(elements...)
+}
+
+// toElement converts a value that is passed to Of or Child to an Element
+func toElement(value any) Element {
+	switch v := value.(type) {
+	case Element:
+		return v
+	case string:
+		return MakeElement(v)
+	case int:
+		return MakeElement(strconv.Itoa(v))
+	default:
+		return MakeElement(fmt.Sprint(v))
+	}
+}
+
+// Join concatenates the given paths. The result is absolute if the first path is absolute. An absolute path after
+// the first one replaces everything before it, just like a relative path is resolved by ToAbsolutePath. The result
+// is not normalized.
+func Join(paths ...*ObjectPath) *ObjectPath {
+	joined := &ObjectPath{Elements{}, false}
+	for i, path := range paths {
+		if i == 0 || path.isAbsolute {
+			joined.elements = joined.elements[:0]
+			joined.isAbsolute = path.isAbsolute
+		}
+		joined.elements = append(joined.elements, path.elements...)
+	}
+	return joined
+}
+
+// Parent returns a copy of the path without its last element. The parent of a path without elements is a copy of
+// the path itself.
+func (p *ObjectPath) Parent() *ObjectPath {
+	parent := &ObjectPath{p.Elements(), p.isAbsolute}
+	if parent.getLength() > 0 {
+		parent.elements = parent.elements[:parent.getLength()-1]
+	}
+	return parent
+}
+
+// Child returns a copy of the path with the given elements appended. The elements are converted like in Of.
+func (p *ObjectPath) Child(elements ...any) *ObjectPath {
+	child := &ObjectPath{make(Elements, 0, p.getLength()+len(elements)), p.isAbsolute}
+	child.elements = append(child.elements, p.elements...)
+	for _, element := range elements {
+		child.elements = append(child.elements, toElement(element))
+	}
+	return child
+}
+
+// HasPrefix returns true if the path starts with all elements of prefix and both are either absolute or relative
+func (p *ObjectPath) HasPrefix(prefix *ObjectPath) bool {
+	if p.isAbsolute != prefix.isAbsolute || p.getLength() < prefix.getLength() {
+		return false
+	}
+	for i, element := range prefix.elements {
+		if p.elements[i] != element {
+			return false
+		}
+	}
+	return true
+}
+
+// Relative returns the relative path that leads from the path from to the path to, e.g. ../type from /payload/kind
+// to /payload/type. Both paths must be absolute and are normalized first.
+func Relative(from *ObjectPath, to *ObjectPath) (*ObjectPath, error) {
+	if !from.IsAbsolutePath() || !to.IsAbsolutePath() {
+		return nil, errors.New("cannot determine a relative path between paths that are not absolute")
+	}
+	from = &ObjectPath{from.Elements(), true}
+	to = &ObjectPath{to.Elements(), true}
+	if err := from.Normalize(); err != nil {
+		return nil, err
+	} else if err := to.Normalize(); err != nil {
+		return nil, err
+	}
+
+	// find the common prefix and go up to it
+	common := 0
+	for common < from.getLength() && common < to.getLength() && from.elements[common] == to.elements[common] {
+		common++
+	}
+	relative := &ObjectPath{Elements{}, false}
+	for i := common; i < from.getLength(); i++ {
+		relative.elements = append(relative.elements, ElementUpwardsReference)
+	}
+	relative.elements = append(relative.elements, to.elements[common:]...)
+	if relative.getLength() == 0 {
+		relative.elements = append(relative.elements, ElementSelfReference)
+	}
+	return relative, nil
+}
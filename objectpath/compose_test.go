@@ -0,0 +1,120 @@
+package objectpath
+
+import "testing"
+
+func TestOf(t *testing.T) {
+	testCases := map[string]*ObjectPath{
+		`items/"0"/type`:     Of("items", 0, "type"),
+		`/items/*/"a/b"`:     Of(ElementRoot, "items", ElementWildcard, "a/b"),
+		`"1.5"/true`:         Of(1.5, true),
+		"":                   Of(),
+		"/":                  Of(ElementRoot),
+		`/payload/"3"/value`: MustParse("/payload").Child(3, "value"),
+	}
+	for expected, path := range testCases {
+		t.Run(expected, func(t *testing.T) {
+			if actual := path.String(); actual != expected {
+				t.Fatalf(`expected "%s", but got "%s"`, expected, actual)
+			}
+		})
+	}
+}
+
+func TestJoin(t *testing.T) {
+	testCases := map[string]*ObjectPath{
+		"/payload/data/type": Join(MustParse("/payload"), MustParse("data"), MustParse("type")),
+		"payload/../type":    Join(MustParse("payload"), MustParse("../type")),
+		"/other/type":        Join(MustParse("/payload"), MustParse("/other"), MustParse("type")),
+		"":                   Join(),
+	}
+	for expected, path := range testCases {
+		t.Run(expected, func(t *testing.T) {
+			if actual := path.String(); actual != expected {
+				t.Fatalf(`expected "%s", but got "%s"`, expected, actual)
+			}
+		})
+	}
+}
+
+func TestObjectPath_Parent(t *testing.T) {
+	path := MustParse("/payload/type")
+	if parent := path.Parent(); parent.String() != "/payload" {
+		t.Fatalf(`expected "/payload", but got "%s"`, parent.String())
+	} else if root := parent.Parent().Parent(); root.String() != "/" {
+		t.Fatalf(`expected "/", but got "%s"`, root.String())
+	} else if path.String() != "/payload/type" {
+		t.Fatalf("expected the path to be unchanged, but got %s", path.String())
+	}
+}
+
+func TestObjectPath_HasPrefix(t *testing.T) {
+	path := MustParse("/items/*/type")
+	testCases := map[string]bool{
+		"/":               true,
+		"/items/*":        true,
+		"/items/*/type":   true,
+		"/items/type":     false,
+		"items":           false,
+		"/items/*/type/a": false,
+	}
+	for prefix, expected := range testCases {
+		t.Run(prefix, func(t *testing.T) {
+			if actual := path.HasPrefix(MustParse(prefix)); actual != expected {
+				t.Fatalf("expected %v, but got %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestRelative(t *testing.T) {
+	type TestCase struct {
+		from, to, expected string
+	}
+	testCases := []TestCase{
+		{"/payload/kind", "/payload/type", "../type"},
+		{"/payload", "/payload/data/type", "data/type"},
+		{"/a/b/c", "/x", "../../../x"},
+		{"/a/./b/..", "/a", "."},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.from+" to "+tc.to, func(t *testing.T) {
+			relative, err := Relative(MustParse(tc.from), MustParse(tc.to))
+			if err != nil {
+				t.Fatalf("error determining relative path: %s", err)
+			} else if relative.String() != tc.expected {
+				t.Fatalf(`expected "%s", but got "%s"`, tc.expected, relative.String())
+			}
+
+			// resolving the relative path must lead to the target again
+			resolved := MustParse(tc.from)
+			if err := relative.ToAbsolutePath(resolved); err != nil {
+				t.Fatalf("error resolving relative path: %s", err)
+			} else if target := MustParse(tc.to); target.Normalize() != nil || !relative.IsEqualTo(target) {
+				t.Fatalf(`expected "%s" to resolve to "%s", but got "%s"`, tc.expected, tc.to, relative.String())
+			}
+		})
+	}
+
+	if _, err := Relative(MustParse("a"), MustParse("/b")); err == nil {
+		t.Fatalf("expected an error for a relative path, but got none")
+	}
+}
+
+func TestObjectPath_ToAbsolutePathDoesNotShareElements(t *testing.T) {
+	reference := MustParse("/payload/inner")
+	reference.elements = append(make(Elements, 0, 8), reference.elements...) // leave spare capacity
+	first := MustParse("../type")
+	second := MustParse("kind")
+	if err := first.ToAbsolutePath(reference); err != nil {
+		t.Fatalf("error converting path: %s", err)
+	} else if err := second.ToAbsolutePath(reference); err != nil {
+		t.Fatalf("error converting path: %s", err)
+	}
+	if s := reference.String(); s != "/payload/inner" {
+		t.Errorf("expected reference path to stay /payload/inner, but got %s", s)
+	} else if s := first.String(); s != "/payload/type" {
+		t.Errorf("expected first path to be /payload/type, but got %s", s)
+	} else if s := second.String(); s != "/payload/inner/kind" {
+		t.Errorf("expected second path to be /payload/inner/kind, but got %s", s)
+	}
+}
@@ -12,6 +12,13 @@ const (
 	ElementTypeUpwardsReference
 	// ElementTypeRoot is the type of Element that is the root element
 	ElementTypeRoot
+	// ElementTypeWildcard is the type of Element that matches any key, index or field ("*")
+	ElementTypeWildcard
+	// ElementTypeRecursiveWildcard is the type of Element that matches any number of levels, including none ("**")
+	ElementTypeRecursiveWildcard
+	// ElementTypeFilter is the type of Element that selects the elements of a collection that match a predicate,
+	// e.g. [?(@.name=="kind")]. The name of the Element is the filter expression without the brackets.
+	ElementTypeFilter
 )
 
 // Element is a single element of a ObjectPath
@@ -34,6 +41,32 @@ var ElementUpwardsReference = Element{"..", ElementTypeUpwardsReference}
 // ElementRoot is a special Element that indicates the root element
 var ElementRoot = Element{"", ElementTypeRoot}
 
+// Name returns the name of the Element
+func (e *Element) Name() string {
+	return e.name
+}
+
+// Type returns the ElementType of the Element
+func (e *Element) Type() ElementType {
+	return e.elementType
+}
+
+// ElementWildcard is a special Element that matches any key, index or field
+var ElementWildcard = Element{"*", ElementTypeWildcard}
+
+// ElementRecursiveWildcard is a special Element that matches any number of levels, including none
+var ElementRecursiveWildcard = Element{"**", ElementTypeRecursiveWildcard}
+
+// IsWildcard returns true if the Element is a wildcard or recursive wildcard element
+func (e *Element) IsWildcard() bool {
+	return e.elementType == ElementTypeWildcard || e.elementType == ElementTypeRecursiveWildcard
+}
+
+// IsFilter returns true if the Element is a filter element
+func (e *Element) IsFilter() bool {
+	return e.elementType == ElementTypeFilter
+}
+
 // IsUpwardsReference returns true if the Element is the upward reference element
 func (e *Element) IsUpwardsReference() bool {
 	return e.elementType == ElementTypeUpwardsReference
@@ -0,0 +1,23 @@
+package objectpath
+
+import "encoding/json"
+
+// MarshalText returns the string representation of the path, see String
+func (p ObjectPath) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText parses the given text like Parse and replaces the path with the result
+func (p *ObjectPath) UnmarshalText(text []byte) error {
+	path, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*p = *path
+	return nil
+}
+
+// MarshalJSON returns the string representation of the path as a JSON string
+func (p ObjectPath) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
@@ -0,0 +1,40 @@
+package objectpath
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestObjectPath_JSON(t *testing.T) {
+	type Config struct {
+		Discriminator ObjectPath  `json:"discriminator"`
+		Target        *ObjectPath `json:"target"`
+	}
+
+	// Arrange
+	input := `{"discriminator":"../event_type","target":"/payload/\"a b\""}`
+
+	// Act
+	var config Config
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("error unmarshalling config: %s", err)
+	}
+	output, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("error marshalling config: %s", err)
+	}
+
+	// Assert
+	if !config.Target.IsAbsolutePath() || config.Target.Elements()[1].Name() != "a b" {
+		t.Fatalf("expected target to be /payload/\"a b\", but got %s", config.Target.String())
+	} else if string(output) != input {
+		t.Fatalf("expected %s, but got %s", input, output)
+	}
+}
+
+func TestObjectPath_UnmarshalTextWithError(t *testing.T) {
+	var path ObjectPath
+	if err := path.UnmarshalText([]byte("fo#")); err == nil {
+		t.Fatalf("expected an error, but got none")
+	}
+}
@@ -0,0 +1,184 @@
+package objectpath
+
+import (
+	"encoding/json"
+	"fmt"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// filter is the compiled expression of a filter Element, e.g. ?(@.name=="kind"). It matches the values whose value
+// at path is equal (or not equal) to value. Without an operator, it matches the values that contain path.
+type filter struct {
+	path     ObjectPath
+	operator string
+	value    any
+}
+
+// filterCache caches the results of compileFilter. It is safe for concurrent use.
+var filterCache sync.Map
+
+// compileFilter compiles a filter expression of the form ?(@.sub.path == literal). The operator may be == or !=, the
+// literal may be a JSON string, number, boolean or null. Without operator and literal, the filter checks that the
+// sub path exists. The results are cached per expression.
+func compileFilter(expression string) (*filter, error) {
+	if f, ok := filterCache.Load(expression); ok {
+		return f.(*filter), nil
+	}
+	f, err := parseFilterExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	filterCache.Store(expression, f)
+	return f, nil
+}
+
+// parseFilterExpression parses a filter expression, see compileFilter.
+func parseFilterExpression(expression string) (*filter, error) {
+	s := strings.TrimSpace(expression)
+	if !strings.HasPrefix(s, "?(") || !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf(`filter [%s] must have the form ?(@.path == value)`, expression)
+	}
+	s = strings.TrimSpace(s[2 : len(s)-1])
+	if !strings.HasPrefix(s, "@") {
+		return nil, fmt.Errorf(`filter [%s] must start with [@]`, expression)
+	}
+	s = s[1:]
+
+	// parse the sub path, e.g. .name or ."some key"
+	f := &filter{path: ObjectPath{Elements{}, false}}
+	for strings.HasPrefix(s, ".") {
+		s = s[1:]
+		var name string
+		if strings.HasPrefix(s, `"`) {
+			end := skipJSONStringLiteral(s)
+			if err := json.Unmarshal([]byte(s[:end]), &name); err != nil {
+				return nil, fmt.Errorf(`filter [%s] contains an invalid quoted name: %s`, expression, err)
+			}
+			s = s[end:]
+		} else {
+			end := strings.IndexFunc(s, func(c rune) bool {
+				return !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '-'
+			})
+			if end < 0 {
+				end = len(s)
+			} else if end == 0 {
+				return nil, fmt.Errorf(`filter [%s] contains an empty name`, expression)
+			}
+			name = s[:end]
+			s = s[end:]
+		}
+		f.path.elements = append(f.path.elements, MakeElement(name))
+	}
+
+	// parse the optional comparison
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return f, nil
+	} else if !strings.HasPrefix(s, "==") && !strings.HasPrefix(s, "!=") {
+		return nil, fmt.Errorf(`filter [%s] contains an unknown operator. Expected either [==] or [!=]`, expression)
+	}
+	f.operator = s[:2]
+	if err := json.Unmarshal([]byte(strings.TrimSpace(s[2:])), &f.value); err != nil {
+		return nil, fmt.Errorf(`filter [%s] contains an invalid value: %s`, expression, err)
+	}
+	switch f.value.(type) {
+	case string, float64, bool, nil:
+		return f, nil
+	default:
+		return nil, fmt.Errorf(`filter [%s] must compare with a string, number, boolean or null`, expression)
+	}
+}
+
+// skipJSONStringLiteral returns the index after the JSON string literal at the beginning of s
+func skipJSONStringLiteral(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// matches returns true if the given value matches the filter. Nested filters are restricted by limits.
+func (f *filter) matches(value reflect.Value, limits Limits) bool {
+	for i := range f.path.elements {
+		var err error
+		if value, err = enterElement(value, f.path, i, limits); err != nil {
+			return false
+		}
+	}
+	if f.operator == "" {
+		return true
+	}
+	actual, ok := normalizeFilterValue(value)
+	equal := ok && actual == f.value
+	if f.operator == "!=" {
+		return !equal
+	}
+	return equal
+}
+
+// normalizeFilterValue converts value to the representation of a filter literal: strings, float64 numbers, booleans
+// and nil. It returns false if the value has no such representation.
+func normalizeFilterValue(value reflect.Value) (any, bool) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, true
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Invalid:
+		return nil, true
+	case reflect.String:
+		return value.String(), true
+	case reflect.Bool:
+		return value.Bool(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return nil, false
+	}
+}
+
+// selectFiltered returns the first element of the collection value that matches the filter element at index i of
+// path. Map entries are checked in the order of their sorted keys. If the collection has more than
+// limits.MaxElements elements, an error.LimitExceededError is returned.
+func selectFiltered(value reflect.Value, path ObjectPath, i int, limits Limits) (reflect.Value, error) {
+	element := path.elements[i]
+	f, err := compileFilter(element.name)
+	if err != nil {
+		return value, err
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return value, &golimorphError.NotTraversableError{Path: path.String(), Index: i, Kind: value.Kind()}
+	}
+	if max := limits.MaxElements; max > 0 && countChildren(value) > max {
+		return value, &golimorphError.LimitExceededError{Path: path.String(), Limit: golimorphError.LimitElements, Max: max}
+	}
+	var selected reflect.Value
+	found := false
+	forEachChild(value, func(_ string, child reflect.Value) bool {
+		if f.matches(child, limits) {
+			selected, found = child, true
+		}
+		return !found
+	})
+	if !found {
+		return value, &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: value.Kind(), Filter: true}
+	}
+	return selected, nil
+}
@@ -0,0 +1,95 @@
+package objectpath
+
+import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"testing"
+)
+
+type Attribute struct {
+	Name  string
+	Value any
+}
+
+func TestGetValueAtPathWithFilter(t *testing.T) {
+	type TestCase struct {
+		source   any
+		path     string
+		expected any
+	}
+	testCases := map[string]TestCase{
+		"string": {map[string]any{"attributes": []any{
+			map[string]any{"name": "color", "value": "red"},
+			map[string]any{"name": "kind", "value": "horse"},
+		}}, `attributes[?(@.name=="kind")]/value`, "horse"},
+		"number": {map[string]any{"items": []any{
+			map[string]any{"id": 1.0, "value": "a"},
+			map[string]any{"id": 2.0, "value": "b"},
+		}}, `items[?(@.id == 2)]/value`, "b"},
+		"not equal": {map[string]any{"items": []any{
+			map[string]any{"id": 1.0, "value": "a"},
+			map[string]any{"id": 2.0, "value": "b"},
+		}}, `items[?(@.id != 1)]/value`, "b"},
+		"existence": {map[string]any{"items": []any{
+			map[string]any{"value": "a"},
+			map[string]any{"value": "b", "default": true},
+		}}, `items[?(@.default)]/value`, "b"},
+		"struct": {struct{ Attributes []Attribute }{[]Attribute{{"kind", "duck"}}}, `attributes[?(@.name=="kind")]/value`, "duck"},
+		"map": {map[string]any{"variants": map[string]any{
+			"a": map[string]any{"enabled": false},
+			"b": map[string]any{"enabled": true, "value": "b"},
+		}}, `variants[?(@.enabled==true)]/value`, "b"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+			var value reflect.Value
+			if err := GetValueAtPath(&tc.source, *MustParse(tc.path), &value); err != nil {
+				t.Fatalf("error getting value: %s", err)
+			}
+
+			// Assert
+			if value.Interface() != tc.expected {
+				t.Fatalf("expected %v, but got %v", tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestGetValueAtPathWithFilterAndError(t *testing.T) {
+	source := map[string]any{"attributes": []any{map[string]any{"name": "color"}}, "name": "kind"}
+	var value reflect.Value
+
+	err := GetValueAtPath(&source, *MustParse(`attributes[?(@.name=="kind")]`), &value)
+	expected := `cannot get value at path [attributes/[?(@.name=="kind")]]: no element of slice matches filter [?(@.name=="kind")] at path index 1`
+	if !errors.Is(err, golimorphError.ErrPathNotFound) || err.Error() != expected {
+		t.Fatalf(`expected error "%s", but got "%v"`, expected, err)
+	}
+
+	if err := GetValueAtPath(&source, *MustParse(`name[?(@)]`), &value); !errors.Is(err, golimorphError.ErrNotTraversable) {
+		t.Fatalf("expected a NotTraversableError, but got %v", err)
+	}
+}
+
+func TestFindAllAtPathWithFilter(t *testing.T) {
+	source := map[string]any{"attributes": []any{
+		map[string]any{"name": "kind", "value": "horse"},
+		map[string]any{"name": "color", "value": "red"},
+		map[string]any{"name": "kind", "value": "duck"},
+	}}
+
+	matches, err := FindAllAtPath(&source, *MustParse(`/attributes[?(@.name=="kind")]/value`))
+	if err != nil {
+		t.Fatalf("error finding values: %s", err)
+	}
+
+	var actual []string
+	for _, match := range matches {
+		actual = append(actual, match.Path.JSONPointer()+"="+match.Value.String())
+	}
+	if expected := []string{"/attributes/0/value=horse", "/attributes/2/value=duck"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected matches %v, but got %v", expected, actual)
+	}
+}
@@ -0,0 +1,236 @@
+package objectpath
+
+import (
+	"errors"
+	"fmt"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Match is a single value that was found by FindAllAtPath
+type Match struct {
+	// Path is the concrete path of the value. It contains no wildcards and is absolute if the searched path is.
+	Path ObjectPath
+	// Value is the matched value
+	Value reflect.Value
+}
+
+// HasWildcards returns true if the path contains a wildcard or recursive wildcard element
+func (p *ObjectPath) HasWildcards() bool {
+	for _, element := range p.elements {
+		if element.IsWildcard() {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAllAtPath returns all values in source that match the given path. The source must be a pointer. The path may
+// contain wildcards: "*" matches every key of a map, index of a slice or array and exported field of a struct,
+// "**" matches any number of levels, including none. Filters match every element of a collection that satisfies
+// the predicate instead of only the first one. Branches of source that do not contain the path are skipped,
+// so a path without wildcards yields no match instead of an error if it does not exist. Other errors, e.g. invalid
+// filters or map keys that cannot be converted, are returned. Map keys are visited in
+// sorted order and the source is traversed depth-first, so that the matches are deterministic. The path must not
+// contain upwards references. The search is restricted by the DefaultLimits.
+func FindAllAtPath(source any, path ObjectPath) ([]Match, error) {
+	return FindAllAtPathWithLimits(source, path, DefaultLimits)
+}
+
+// FindAllAtPathWithLimits is like FindAllAtPath, but uses the given limits instead of the DefaultLimits. If a limit
+// is exceeded or a pointer cycle is found, an error.LimitExceededError is returned.
+func FindAllAtPathWithLimits(source any, path ObjectPath, limits Limits) ([]Match, error) {
+	value := reflect.ValueOf(source)
+	if value.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf(`cannot find values at path [%s]: source is not a pointer`, path.String())
+	}
+	for _, element := range path.elements {
+		if element.IsUpwardsReference() {
+			return nil, fmt.Errorf(`cannot find values at path [%s]: path contains upwards references`, path.String())
+		}
+	}
+	if err := checkPathLength(path, limits.MaxPathLength); err != nil {
+		return nil, err
+	}
+	t := traversal{path: path, limits: limits, stack: map[cycleKey]bool{}}
+	concretePath := ObjectPath{Elements{}, path.isAbsolute}
+	if err := t.findAll(value.Elem(), path.elements, concretePath); err != nil {
+		return nil, err
+	}
+	return t.matches, nil
+}
+
+// traversal is the state of a single FindAllAtPathWithLimits call
+type traversal struct {
+	path    ObjectPath
+	limits  Limits
+	visited int
+	stack   map[cycleKey]bool
+	matches []Match
+}
+
+// findAll appends every value below value that matches the given elements to the matches. concretePath is the path
+// of value itself.
+func (t *traversal) findAll(value reflect.Value, elements Elements, concretePath ObjectPath) error {
+	if t.limits.MaxDepth > 0 && concretePath.getLength() > t.limits.MaxDepth {
+		return &golimorphError.LimitExceededError{Path: t.path.String(), Limit: golimorphError.LimitDepth, Max: t.limits.MaxDepth}
+	}
+	if len(elements) == 0 {
+		t.matches = append(t.matches, Match{concretePath, value})
+		return nil
+	}
+	element := elements[0]
+	switch element.elementType {
+	case ElementTypeSelfReference:
+		return t.findAll(value, elements[1:], concretePath)
+	case ElementTypeWildcard:
+		return t.forEachChild(value, func(name string, child reflect.Value) error {
+			return t.findAll(child, elements[1:], concretePath.withElement(MakeElement(name)))
+		})
+	case ElementTypeFilter:
+		f, err := compileFilter(element.name)
+		if err != nil {
+			return err
+		}
+		switch value.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			value = value.Elem()
+		}
+		switch value.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return t.forEachChild(value, func(name string, child reflect.Value) error {
+				if !f.matches(child, t.limits) {
+					return nil
+				}
+				return t.findAll(child, elements[1:], concretePath.withElement(MakeElement(name)))
+			})
+		}
+		return nil
+	case ElementTypeRecursiveWildcard:
+		if err := t.findAll(value, elements[1:], concretePath); err != nil {
+			return err
+		}
+
+		// keep the referenced values of the current branch on the stack to detect cycles
+		if key, ok := referenceKey(value); ok {
+			if t.stack[key] {
+				return &golimorphError.LimitExceededError{Path: t.path.String(), Limit: golimorphError.LimitCycle}
+			}
+			t.stack[key] = true
+			defer delete(t.stack, key)
+		}
+		return t.forEachChild(value, func(name string, child reflect.Value) error {
+			return t.findAll(child, elements, concretePath.withElement(MakeElement(name)))
+		})
+	default:
+		child, err := enterElement(value, ObjectPath{elements[:1], false}, 0, t.limits)
+		if errors.Is(err, golimorphError.ErrPathNotFound) || errors.Is(err, golimorphError.ErrNotTraversable) {
+			return nil // the branch does not contain the path
+		} else if err != nil {
+			return err
+		}
+		return t.findAll(child, elements[1:], concretePath.withElement(element))
+	}
+}
+
+// forEachChild calls fn for each child of value like the forEachChild function, but counts the visited children
+// against the MaxElements limit. It stops at the first error.
+func (t *traversal) forEachChild(value reflect.Value, fn func(name string, child reflect.Value) error) error {
+	t.visited += countChildren(value)
+	if t.limits.MaxElements > 0 && t.visited > t.limits.MaxElements {
+		return &golimorphError.LimitExceededError{Path: t.path.String(), Limit: golimorphError.LimitElements, Max: t.limits.MaxElements}
+	}
+	var err error
+	forEachChild(value, func(name string, child reflect.Value) bool {
+		err = fn(name, child)
+		return err == nil
+	})
+	return err
+}
+
+// forEachChild calls fn for each map entry, slice or array element or exported struct field of value until fn
+// returns false
+func forEachChild(value reflect.Value, fn func(name string, child reflect.Value) bool) {
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if !fn(strconv.Itoa(i), unwrapInterface(value.Index(i))) {
+				return
+			}
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		names := make([]string, len(keys))
+		for i, key := range keys {
+			names[i] = fmt.Sprint(key.Interface())
+		}
+		order := make([]int, len(keys))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(a, b int) bool { return names[order[a]] < names[order[b]] })
+		for _, i := range order {
+			if !fn(names[i], unwrapInterface(value.MapIndex(keys[i]))) {
+				return
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() && !fn(value.Type().Field(i).Name, value.Field(i)) {
+				return
+			}
+		}
+	}
+}
+
+// unwrapInterface returns the value that is stored in value if it is an interface, otherwise value itself
+func unwrapInterface(value reflect.Value) reflect.Value {
+	if value.Kind() == reflect.Interface {
+		return value.Elem()
+	}
+	return value
+}
+
+// withElement returns a copy of the path with the given element appended. The elements of p are not modified.
+func (p ObjectPath) withElement(element Element) ObjectPath {
+	elements := make(Elements, len(p.elements), len(p.elements)+1)
+	copy(elements, p.elements)
+	return ObjectPath{append(elements, element), p.isAbsolute}
+}
+
+// ResolveWildcards returns a copy of p whose wildcards are replaced by the elements of a concrete path, which is a
+// match of pattern, e.g. /items/*/type becomes /items/3/type for the pattern /items/* and the concrete path
+// /items/3. p must share all of its wildcards with pattern, and the elements of pattern after the common prefix must
+// not be wildcards. A path without wildcards is returned as it is.
+func (p *ObjectPath) ResolveWildcards(pattern ObjectPath, concrete ObjectPath) (*ObjectPath, error) {
+	if !p.HasWildcards() {
+		return &ObjectPath{p.Elements(), p.isAbsolute}, nil
+	}
+
+	// find the common prefix of p and pattern
+	prefixLength := 0
+	for prefixLength < p.getLength() && prefixLength < pattern.getLength() &&
+		p.elements[prefixLength] == pattern.elements[prefixLength] {
+		prefixLength++
+	}
+	patternSuffix := ObjectPath{pattern.elements[prefixLength:], false}
+	suffix := ObjectPath{p.elements[prefixLength:], false}
+	if patternSuffix.HasWildcards() || suffix.HasWildcards() {
+		return nil, fmt.Errorf(`cannot resolve wildcards of path [%s]: the wildcards are not shared with [%s]`, p.String(), pattern.String())
+	}
+
+	// the elements of the pattern suffix match exactly one concrete element each
+	concreteLength := concrete.getLength() - patternSuffix.getLength()
+	if concreteLength < 0 {
+		return nil, fmt.Errorf(`cannot resolve wildcards of path [%s]: [%s] is no match of [%s]`, p.String(), concrete.String(), pattern.String())
+	}
+	elements := make(Elements, 0, concreteLength+suffix.getLength())
+	elements = append(elements, concrete.elements[:concreteLength]...)
+	return &ObjectPath{append(elements, suffix.elements...), p.isAbsolute}, nil
+}
@@ -0,0 +1,109 @@
+package objectpath
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindAllAtPath(t *testing.T) {
+	source := map[string]any{
+		"items": []any{
+			map[string]any{"payload": map[string]any{"type": "a"}},
+			map[string]any{"payload": map[string]any{"type": "b", "nested": map[string]any{"payload": "c"}}},
+		},
+		"payload": "d",
+	}
+	testCases := map[string][]string{
+		"/items/*/payload/type": {"/items/0/payload/type", "/items/1/payload/type"},
+		"/**/payload":           {"/payload", "/items/0/payload", "/items/1/payload", "/items/1/payload/nested/payload"},
+		"/items/*/missing":      nil,
+		"/payload":              {"/payload"},
+		"/*/*":                  {"/items/0", "/items/1"},
+	}
+	for input, expected := range testCases {
+		t.Run(input, func(t *testing.T) {
+
+			// Act
+			matches, err := FindAllAtPath(&source, *MustParse(input))
+			if err != nil {
+				t.Fatalf("error finding values: %s", err)
+			}
+
+			// Assert
+			var actual []string
+			for _, match := range matches {
+				if match.Path.HasWildcards() {
+					t.Errorf("expected concrete path, but got %s", match.Path.String())
+				}
+				actual = append(actual, match.Path.JSONPointer())
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("expected matches %v, but got %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestFindAllAtPathInStruct(t *testing.T) {
+	source := struct{ Animals []Animal }{[]Animal{{"Horsey", Horse{4}}, {"Ducky", Duck{1000}}}}
+
+	matches, err := FindAllAtPath(&source, *MustParse("animals/*/specifics"))
+	if err != nil {
+		t.Fatalf("error finding values: %s", err)
+	} else if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, but got %d", len(matches))
+	} else if matches[1].Value.Interface() != (Duck{1000}) {
+		t.Fatalf("expected second match to be a duck, but got %v", matches[1].Value)
+	} else if pointer := matches[1].Path.JSONPointer(); pointer != "/animals/1/specifics" {
+		t.Fatalf(`expected path "/animals/1/specifics", but got "%s"`, pointer)
+	}
+}
+
+func TestGetValueAtPathWithWildcard(t *testing.T) {
+	source := map[string]any{"items": []any{}}
+	var value reflect.Value
+	if err := GetValueAtPath(&source, *MustParse("items/*"), &value); err == nil {
+		t.Fatalf("expected an error for a wildcard path, but got none")
+	}
+}
+
+func TestObjectPath_ResolveWildcards(t *testing.T) {
+	type TestCase struct {
+		path, pattern, concrete, expected string
+	}
+	testCases := []TestCase{
+		{"/items/*/payload/type", "/items/*/payload", "#/items/3/payload", `/items/"3"/payload/type`},
+		{"/items/*/type", "/items/*/payload", "#/items/3/payload", `/items/"3"/type`},
+		{"/**/type", "/**", "#/a/b", `/a/b/type`},
+		{"/version", "/items/*", "#/items/3", `/version`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			resolved, err := MustParse(tc.path).ResolveWildcards(*MustParse(tc.pattern), *MustParse(tc.concrete))
+			if err != nil {
+				t.Fatalf("error resolving wildcards: %s", err)
+			} else if resolved.String() != tc.expected {
+				t.Fatalf(`expected "%s", but got "%s"`, tc.expected, resolved.String())
+			}
+		})
+	}
+
+	if _, err := MustParse("/other/*").ResolveWildcards(*MustParse("/items/*"), *MustParse("#/items/3")); err == nil {
+		t.Fatalf("expected an error for wildcards that are not shared, but got none")
+	}
+}
+
+func TestFindAllAtPathWithError(t *testing.T) {
+	source := map[int]any{1: map[string]any{"type": "a"}}
+	testCases := map[string]*ObjectPath{
+		"invalid filter":        Of(ElementRoot, Element{"?(name)", ElementTypeFilter}),
+		"key conversion failed": Of(ElementRoot, "one", "type"),
+	}
+	for name, path := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := FindAllAtPath(&source, *path); err == nil {
+				t.Fatalf("expected an error for path %s, but got none", path.String())
+			}
+		})
+	}
+}
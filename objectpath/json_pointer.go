@@ -0,0 +1,63 @@
+package objectpath
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParseJSONPointer creates a new absolute ObjectPath from an RFC 6901 JSON Pointer, e.g. /items/0/type. The escape
+// sequences ~0 and ~1 are decoded to ~ and /. All reference tokens are identifiers, i.e. "." and ".." have no
+// special meaning. The empty pointer references the whole document.
+func ParseJSONPointer(s string) (*ObjectPath, error) {
+	path := &ObjectPath{Elements{}, true}
+	if s == "" {
+		return path, nil
+	} else if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf(`invalid JSON pointer [%s]: a non-empty JSON pointer must start with [/]`, s)
+	}
+	offset := 1
+	for _, token := range strings.Split(s[1:], "/") {
+		name, err := unescapeJSONPointerToken(token, offset)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid JSON pointer [%s]: %s`, s, err)
+		}
+		path.elements = append(path.elements, MakeElement(name))
+		offset += len(token) + 1
+	}
+	return path, nil
+}
+
+// parseJSONPointerFragment creates a new ObjectPath from the URI fragment identifier representation of a JSON Pointer,
+// e.g. #/items/0/type. Percent-encoded characters are decoded before the pointer is parsed.
+func parseJSONPointerFragment(s string) (*ObjectPath, error) {
+	pointer, err := url.PathUnescape(strings.TrimPrefix(s, "#"))
+	if err != nil {
+		return nil, fmt.Errorf(`invalid JSON pointer fragment [%s]: %s`, s, err)
+	}
+	return ParseJSONPointer(pointer)
+}
+
+// unescapeJSONPointerToken decodes the escape sequences of a JSON Pointer reference token. offset is the index of the
+// token in the pointer and is used for error messages.
+func unescapeJSONPointerToken(token string, offset int) (string, error) {
+	if !strings.Contains(token, "~") {
+		return token, nil
+	}
+	var name strings.Builder
+	for i := 0; i < len(token); i++ {
+		if token[i] != '~' {
+			name.WriteByte(token[i])
+			continue
+		} else if i+1 == len(token) || (token[i+1] != '0' && token[i+1] != '1') {
+			return "", fmt.Errorf(`invalid escape sequence at index %d. Expected [~0] or [~1]`, offset+i)
+		}
+		if token[i+1] == '0' {
+			name.WriteByte('~')
+		} else {
+			name.WriteByte('/')
+		}
+		i++
+	}
+	return name.String(), nil
+}
@@ -0,0 +1,66 @@
+package objectpath
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseJSONPointer(t *testing.T) {
+	type TestCase struct {
+		input  string
+		output []Element
+	}
+	testCases := []TestCase{
+		{"", []Element{}},
+		{"/", []Element{MakeElement("")}},
+		{"/items/0/type", PathElementsFromStringArray([]string{"items", "0", "type"})},
+		{"/a~1b/m~0n/~01", PathElementsFromStringArray([]string{"a/b", "m~n", "~1"})},
+		{"/../.", PathElementsFromStringArray([]string{"..", "."})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf(`ParseJSONPointer with input "%s"`, tc.input), func(t *testing.T) {
+			path, err := ParseJSONPointer(tc.input)
+			if err != nil {
+				t.Fatalf("error parsing JSON pointer: %s", err)
+			} else if !path.IsAbsolutePath() {
+				t.Fatalf("expected JSON pointer to be absolute")
+			} else if expected := (ObjectPath{tc.output, true}); !path.IsEqualTo(&expected) {
+				t.Fatalf(`expected "%v", but got "%v"`, tc.output, path.elements)
+			} else if pointer := path.JSONPointer(); pointer != tc.input {
+				t.Fatalf(`expected JSON pointer to be formatted as "%s", but got "%s"`, tc.input, pointer)
+			}
+		})
+	}
+}
+
+func TestParseJSONPointerWithError(t *testing.T) {
+	testCases := map[string]string{
+		"items":   `invalid JSON pointer [items]: a non-empty JSON pointer must start with [/]`,
+		"/a~2":    `invalid JSON pointer [/a~2]: invalid escape sequence at index 2. Expected [~0] or [~1]`,
+		"/a/b~":   `invalid JSON pointer [/a/b~]: invalid escape sequence at index 4. Expected [~0] or [~1]`,
+		"#/a%zz":  `invalid JSON pointer fragment [#/a%zz]: invalid URL escape "%zz"`,
+		"#items/": `invalid JSON pointer [items/]: a non-empty JSON pointer must start with [/]`,
+	}
+
+	for input, expected := range testCases {
+		t.Run(input, func(t *testing.T) {
+			parse := ParseJSONPointer
+			if input[0] == '#' {
+				parse = Parse
+			}
+			if _, err := parse(input); err == nil || err.Error() != expected {
+				t.Fatalf(`expected error "%s", but got "%v"`, expected, err)
+			}
+		})
+	}
+}
+
+func TestParseWithJSONPointerFragment(t *testing.T) {
+	path, err := Parse("#/items/0/content%20type")
+	if err != nil {
+		t.Fatalf("error parsing JSON pointer fragment: %s", err)
+	} else if pointer := path.JSONPointer(); pointer != "/items/0/content type" {
+		t.Fatalf(`expected "/items/0/content type", but got "%s"`, pointer)
+	}
+}
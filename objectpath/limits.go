@@ -0,0 +1,68 @@
+package objectpath
+
+import (
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+)
+
+// Limits restrict the resources that are spent on parsing paths and traversing values, so that adversarial paths and
+// payloads cannot exhaust them. A zero field disables the respective limit. Pointer cycles are always detected.
+type Limits struct {
+	// MaxPathLength is the maximum number of elements of a path
+	MaxPathLength int
+	// MaxDepth is the maximum nesting depth of the values that are traversed by a search, e.g. by "**"
+	MaxDepth int
+	// MaxElements is the maximum number of collection elements, map entries and struct fields that are visited by a
+	// single search with wildcards or filters, and the maximum length up to which EnsurePathWithLimits grows a slice
+	MaxElements int
+}
+
+// DefaultLimits are the Limits that are used by Parse, GetValueAtPath, FindAllAtPath and EnsurePath
+var DefaultLimits = Limits{MaxPathLength: 256, MaxDepth: 128, MaxElements: 1_000_000}
+
+// checkPathLength returns an error.LimitExceededError if path has more than maxPathLength elements
+func checkPathLength(path ObjectPath, maxPathLength int) error {
+	if maxPathLength > 0 && path.getLength() > maxPathLength {
+		return &golimorphError.LimitExceededError{Path: path.String(), Limit: golimorphError.LimitPathLength, Max: maxPathLength}
+	}
+	return nil
+}
+
+// countChildren returns the number of children that forEachChild visits for value
+func countChildren(value reflect.Value) int {
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return value.Len()
+	case reflect.Struct:
+		return value.NumField()
+	default:
+		return 0
+	}
+}
+
+// cycleKey identifies a referenced value on the stack of a traversal. Slices also differ in their length, since a
+// slice may share its array with a shorter slice it contains.
+type cycleKey struct {
+	pointer   uintptr
+	valueType reflect.Type
+	length    int
+}
+
+// referenceKey returns the cycleKey of value and true if value is a non-nil pointer, map or slice
+func referenceKey(value reflect.Value) (cycleKey, bool) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map:
+		if !value.IsNil() {
+			return cycleKey{value.Pointer(), value.Type(), 0}, true
+		}
+	case reflect.Slice:
+		if !value.IsNil() {
+			return cycleKey{value.Pointer(), value.Type(), value.Len()}, true
+		}
+	}
+	return cycleKey{}, false
+}
@@ -0,0 +1,140 @@
+package objectpath
+
+import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type node struct {
+	Name string
+	Next *node
+}
+
+func expectLimitExceeded(t *testing.T, err error, limit string) {
+	t.Helper()
+	var limitError *golimorphError.LimitExceededError
+	if !errors.As(err, &limitError) {
+		t.Fatalf("expected a LimitExceededError, but got %v", err)
+	} else if limitError.Limit != limit {
+		t.Fatalf("expected limit [%s] to be exceeded, but got [%s]", limit, limitError.Limit)
+	} else if !errors.Is(err, golimorphError.ErrLimitExceeded) {
+		t.Fatalf("expected error to match ErrLimitExceeded")
+	}
+}
+
+func TestParseWithOptions_MaxPathLength(t *testing.T) {
+	options := ParseOptions{Sigils: DefaultSigils, MaxPathLength: 3}
+	if _, err := ParseWithOptions("/a/b/c", options); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	}
+	_, err := ParseWithOptions("/a/b/c/d", options)
+	expectLimitExceeded(t, err, golimorphError.LimitPathLength)
+	_, err = ParseWithOptions("#/a/b/c/d", options)
+	expectLimitExceeded(t, err, golimorphError.LimitPathLength)
+	_, err = Parse(strings.Repeat("/a", DefaultLimits.MaxPathLength+1))
+	expectLimitExceeded(t, err, golimorphError.LimitPathLength)
+}
+
+func TestGetValueAtPath_MaxPathLength(t *testing.T) {
+	elements := make([]any, DefaultLimits.MaxPathLength+1)
+	for i := range elements {
+		elements[i] = "a"
+	}
+	source := map[string]any{}
+	err := GetValueAtPath(&source, *Of(elements...), nil)
+	expectLimitExceeded(t, err, golimorphError.LimitPathLength)
+}
+
+func TestFindAllAtPathWithLimits_MaxDepth(t *testing.T) {
+	source := map[string]any{"a": map[string]any{"b": map[string]any{"c": map[string]any{"type": "deep"}}}}
+	path := MustParse("/**/type")
+	if matches, err := FindAllAtPathWithLimits(&source, *path, Limits{MaxDepth: 4}); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	} else if len(matches) != 1 {
+		t.Fatalf("expected 1 match, but got %d", len(matches))
+	}
+	_, err := FindAllAtPathWithLimits(&source, *path, Limits{MaxDepth: 3})
+	expectLimitExceeded(t, err, golimorphError.LimitDepth)
+}
+
+func TestFindAllAtPathWithLimits_MaxElements(t *testing.T) {
+	source := map[string]any{"items": []any{1, 2, 3, 4}}
+	path := MustParse("/items/*")
+	if matches, err := FindAllAtPathWithLimits(&source, *path, Limits{MaxElements: 4}); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	} else if len(matches) != 4 {
+		t.Fatalf("expected 4 matches, but got %d", len(matches))
+	}
+	_, err := FindAllAtPathWithLimits(&source, *path, Limits{MaxElements: 3})
+	expectLimitExceeded(t, err, golimorphError.LimitElements)
+}
+
+func TestFindAllAtPath_PointerCycle(t *testing.T) {
+	first := &node{Name: "first"}
+	first.Next = &node{Name: "second", Next: first}
+	_, err := FindAllAtPathWithLimits(&first, *MustParse("/**/name"), Limits{})
+	expectLimitExceeded(t, err, golimorphError.LimitCycle)
+
+	cyclic := []any{nil}
+	cyclic[0] = cyclic
+	_, err = FindAllAtPathWithLimits(&cyclic, *MustParse("/**/name"), Limits{})
+	expectLimitExceeded(t, err, golimorphError.LimitCycle)
+}
+
+func TestFindAllAtPath_SharedPointerIsNoCycle(t *testing.T) {
+	shared := &node{Name: "shared"}
+	source := map[string]*node{"a": shared, "b": shared}
+	matches, err := FindAllAtPath(&source, *MustParse("/**/name"))
+	if err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	} else if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, but got %d", len(matches))
+	}
+}
+
+func TestGetValueAtPathWithLimits(t *testing.T) {
+	path, err := ParseWithOptions(strings.Repeat("/a", DefaultLimits.MaxPathLength+1), ParseOptions{})
+	if err != nil {
+		t.Fatalf("error parsing path without limit: %s", err)
+	}
+	var source any = "leaf"
+	for i := 0; i < path.getLength(); i++ {
+		source = map[string]any{"a": source}
+	}
+	var value reflect.Value
+	if err := GetValueAtPathWithLimits(&source, *path, Limits{}, &value); err != nil {
+		t.Fatalf("expected no error without limits, but got %s", err)
+	} else if value.Interface() != "leaf" {
+		t.Fatalf(`expected value to be "leaf", but got %v`, value)
+	}
+
+	filtered := map[string]any{"items": []any{map[string]any{"kind": "a"}, map[string]any{"kind": "b"}}}
+	filterPath := MustParse(`/items[?(@.kind=="b")]`)
+	if err := GetValueAtPathWithLimits(&filtered, *filterPath, Limits{MaxElements: 2}, &value); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	}
+	err = GetValueAtPathWithLimits(&filtered, *filterPath, Limits{MaxElements: 1}, &value)
+	expectLimitExceeded(t, err, golimorphError.LimitElements)
+}
+
+func TestEnsurePathWithLimits(t *testing.T) {
+	var source struct{ Items []node }
+	var value reflect.Value
+	if err := EnsurePathWithLimits(&source, *MustParse("#/items/2/name"), Limits{MaxElements: 3}, &value); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	} else if len(source.Items) != 3 {
+		t.Fatalf("expected the slice to grow to 3 elements, but got %d", len(source.Items))
+	}
+	err := EnsurePathWithLimits(&source, *MustParse("#/items/3/name"), Limits{MaxElements: 3}, &value)
+	expectLimitExceeded(t, err, golimorphError.LimitElements)
+
+	// a huge index must not be allocated
+	err = EnsurePath(&source, *MustParse("#/items/20000000/name"), &value)
+	expectLimitExceeded(t, err, golimorphError.LimitElements)
+	if len(source.Items) != 3 {
+		t.Fatalf("expected the slice not to grow, but it has %d elements", len(source.Items))
+	}
+}
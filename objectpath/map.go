@@ -2,8 +2,11 @@ package objectpath
 
 import (
 	"fmt"
+	golimorphError "github.com/SoulKa/golymorph/error"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 func compareStringsIgnoreCase(target string) func(string) bool {
@@ -13,46 +16,131 @@ func compareStringsIgnoreCase(target string) func(string) bool {
 	}
 }
 
+// fieldCacheKey identifies a field lookup by name within a struct type
+type fieldCacheKey struct {
+	structType reflect.Type
+	name       string
+}
+
+// fieldCacheEntry is the cached result of a field lookup. If found is false, the struct has no such field.
+type fieldCacheEntry struct {
+	index []int
+	found bool
+}
+
+// fieldCache caches the results of lookupField. It is safe for concurrent use.
+var fieldCache sync.Map
+
+// lookupField returns the index chain of the field of structType whose name matches name case-insensitively.
+// The results are cached per struct type and name.
+func lookupField(structType reflect.Type, name string) ([]int, bool) {
+	key := fieldCacheKey{structType, name}
+	if entry, ok := fieldCache.Load(key); ok {
+		return entry.(fieldCacheEntry).index, entry.(fieldCacheEntry).found
+	}
+	field, found := structType.FieldByNameFunc(compareStringsIgnoreCase(name))
+	fieldCache.Store(key, fieldCacheEntry{field.Index, found})
+	return field.Index, found
+}
+
+// FieldByName returns the field of structType whose name matches name case-insensitively. Fields are matched exactly
+// like GetValueAtPath and all other functions of this package match them, so that paths can be translated by type.
+func FieldByName(structType reflect.Type, name string) (reflect.StructField, bool) {
+	index, found := lookupField(structType, name)
+	if !found {
+		return reflect.StructField{}, false
+	}
+	return structType.FieldByIndex(index), true
+}
+
 // GetValueAtPath returns the value at the given path in source. The source must be a pointer.
-// The value is returned as a reflect.Value in out.
+// The value is returned as a reflect.Value in out. Elements of slices and arrays are addressed by their decimal
+// index, e.g. items/"3" or #/items/3. Indices that are negative, out of range or no number return an
+// error.PathNotFoundError. The traversal is restricted by the DefaultLimits.
 func GetValueAtPath(source any, path ObjectPath, out *reflect.Value) error {
+	return GetValueAtPathWithLimits(source, path, DefaultLimits, out)
+}
+
+// GetValueAtPathWithLimits is like GetValueAtPath, but uses the given limits instead of the DefaultLimits. If the path
+// is longer than MaxPathLength or a filter has to check more than MaxElements elements, an
+// error.LimitExceededError is returned.
+func GetValueAtPathWithLimits(source any, path ObjectPath, limits Limits, out *reflect.Value) error {
 	value := reflect.ValueOf(source)
 	if value.Kind() != reflect.Ptr {
 		return fmt.Errorf(`cannot get value at path [%s]: source is not a pointer`, path.String())
 	}
 	value = value.Elem()
+	if err := checkPathLength(path, limits.MaxPathLength); err != nil {
+		return err
+	}
 
 	// Iterate over path elements
-	for i, element := range path.elements {
-
-		// Check if the value is zero or nil
-		if !value.IsValid() {
-			return fmt.Errorf(`cannot enter field [%s] of path [%s] at index %d: value is zero or nil`, element.name, path.String(), i)
+	for i := range path.elements {
+		var err error
+		if value, err = enterElement(value, path, i, limits); err != nil {
+			return err
 		}
+	}
+	*out = value
+	return nil
+}
 
-		// Dereference pointer
-		switch value.Kind() {
-		case reflect.Interface, reflect.Ptr:
-			value = value.Elem()
-		}
+// enterElement returns the child of value that is described by the element at index i of path. Filters are
+// restricted by limits.
+func enterElement(value reflect.Value, path ObjectPath, i int, limits Limits) (reflect.Value, error) {
+	element := path.elements[i]
+	if element.IsWildcard() {
+		return value, fmt.Errorf(`cannot enter wildcard element [%s] at index %d of path [%s]: use FindAllAtPath instead`, element.name, i, path.String())
+	}
+
+	// Check if the value is zero or nil
+	if !value.IsValid() {
+		return value, &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Invalid}
+	}
 
-		// Check if we're working with a map or a struct
-		switch value.Kind() {
-		case reflect.Map:
-			value = value.MapIndex(reflect.ValueOf(element.name)).Elem()
-		case reflect.Struct:
-			valueType := value.Type()
-			field, ok := valueType.FieldByNameFunc(compareStringsIgnoreCase(element.name))
-			if !ok {
-				return fmt.Errorf(`cannot get value at path "%s": field "%s" not found in struct at path index %d`, path.String(), element.name, i)
-			}
-			value = value.FieldByIndex(field.Index)
-		default:
-			return fmt.Errorf(`cannot get value at path [%s]: value at path index %d is neither a map nor struct`, path.String(), i)
+	// Dereference pointer
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		value = value.Elem()
+	}
+
+	if element.elementType == ElementTypeFilter {
+		return selectFiltered(value, path, i, limits)
+	}
+
+	// Check if we're working with a map, a struct or a collection
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(element.name)
+		if err != nil || index < 0 || index >= value.Len() {
+			return value, &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: value.Kind()}
+		}
+		child := value.Index(index)
+		if child.Kind() == reflect.Interface {
+			child = child.Elem()
 		}
+		return child, nil
+	case reflect.Map:
+		key, err := mapKey(value, path, i)
+		if err != nil {
+			return value, err
+		}
+		child := value.MapIndex(key)
+		if !child.IsValid() {
+			return child, &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Map}
+		} else if child.Kind() == reflect.Interface {
+			child = child.Elem()
+		}
+		return child, nil
+	case reflect.Struct:
+		index, ok := lookupField(value.Type(), element.name)
+		if !ok {
+			return value, &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Struct}
+		}
+		return value.FieldByIndexErr(index)
+	default:
+		return value, &golimorphError.NotTraversableError{Path: path.String(), Index: i, Kind: value.Kind()}
 	}
-	*out = value
-	return nil
 }
 
 // AssignTypeAtPath assigns the given reflect.Type to the value at the given path in source.
@@ -63,6 +151,19 @@ func AssignTypeAtPath(source any, path ObjectPath, newType reflect.Type) error {
 		return err
 	}
 
+	return assignType(value, path, newType)
+}
+
+// assignType sets value to the zero value of newType. It returns an error.TypeNotAssignableError if this is not possible.
+func assignType(value reflect.Value, path ObjectPath, newType reflect.Type) error {
+	if !value.IsValid() {
+		return &golimorphError.TypeNotAssignableError{Path: path.String(), Type: newType, Reason: "the value does not exist"}
+	} else if !newType.AssignableTo(value.Type()) {
+		return &golimorphError.TypeNotAssignableError{Path: path.String(), Type: newType, TargetType: value.Type()}
+	} else if !value.CanSet() {
+		return &golimorphError.TypeNotAssignableError{Path: path.String(), Type: newType, TargetType: value.Type(), Reason: "the value cannot be set, e.g. because it is stored in a map or an interface"}
+	}
+
 	// Set the new type
 	value.Set(reflect.New(newType).Elem())
 	return nil
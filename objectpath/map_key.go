@@ -0,0 +1,102 @@
+package objectpath
+
+import (
+	"encoding"
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"strconv"
+)
+
+var stringType = reflect.TypeOf("")
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// mapKey returns the key of the map value that is described by the element at index i of path. The element is
+// converted to the key type of the map, see convertMapKey. For interface key types, e.g. of maps decoded from YAML,
+// the element is tried as string, integer, float and boolean in this order, and the first key that exists in the map
+// is returned. If none exists, the first candidate is returned, so that it can be used to add an entry.
+func mapKey(value reflect.Value, path ObjectPath, i int) (reflect.Value, error) {
+	keyType := value.Type().Key()
+	name := path.elements[i].name
+	if keyType == stringType {
+		return reflect.ValueOf(name), nil
+	} else if keyType.Kind() != reflect.Interface {
+		key, err := convertMapKey(keyType, name)
+		if err != nil {
+			return key, &golimorphError.KeyConversionError{Path: path.String(), Index: i, Element: name, KeyType: keyType, Err: err}
+		}
+		return key, nil
+	}
+
+	// try all candidates that the interface can hold
+	var candidates []reflect.Value
+	for _, candidate := range interfaceKeyCandidates(name) {
+		if candidate.Type().AssignableTo(keyType) {
+			if value.MapIndex(candidate).IsValid() {
+				return candidate, nil
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		err := errors.New("the key type cannot hold a string, number or boolean")
+		return reflect.Value{}, &golimorphError.KeyConversionError{Path: path.String(), Index: i, Element: name, KeyType: keyType, Err: err}
+	}
+	return candidates[0], nil
+}
+
+// interfaceKeyCandidates returns the values that the given name may represent as key of a map with interface keys
+func interfaceKeyCandidates(name string) []reflect.Value {
+	candidates := []reflect.Value{reflect.ValueOf(name)}
+	if number, err := strconv.Atoi(name); err == nil {
+		candidates = append(candidates, reflect.ValueOf(number))
+	}
+	if number, err := strconv.ParseFloat(name, 64); err == nil {
+		candidates = append(candidates, reflect.ValueOf(number))
+	}
+	if boolean, err := strconv.ParseBool(name); err == nil {
+		candidates = append(candidates, reflect.ValueOf(boolean))
+	}
+	return candidates
+}
+
+// convertMapKey converts name to a value of keyType. Types implementing encoding.TextUnmarshaler are decoded with
+// UnmarshalText, string types are converted, and integer, float and boolean types are parsed.
+func convertMapKey(keyType reflect.Type, name string) (reflect.Value, error) {
+	key := reflect.New(keyType).Elem()
+	if keyType.Kind() != reflect.String && reflect.PointerTo(keyType).Implements(textUnmarshalerType) {
+		err := key.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(name))
+		return key, err
+	}
+	switch keyType.Kind() {
+	case reflect.String:
+		key.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(name, 10, keyType.Bits())
+		if err != nil {
+			return key, err
+		}
+		key.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number, err := strconv.ParseUint(name, 10, keyType.Bits())
+		if err != nil {
+			return key, err
+		}
+		key.SetUint(number)
+	case reflect.Float32, reflect.Float64:
+		number, err := strconv.ParseFloat(name, keyType.Bits())
+		if err != nil {
+			return key, err
+		}
+		key.SetFloat(number)
+	case reflect.Bool:
+		boolean, err := strconv.ParseBool(name)
+		if err != nil {
+			return key, err
+		}
+		key.SetBool(boolean)
+	default:
+		return key, errors.New("unsupported key type")
+	}
+	return key, nil
+}
@@ -0,0 +1,83 @@
+package objectpath
+
+import (
+	"errors"
+	"fmt"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type Color string
+
+// Point is a map key that is decoded from text like "1,2"
+type Point struct{ X, Y int }
+
+func (p *Point) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d,%d", &p.X, &p.Y)
+	return err
+}
+
+func TestGetValueAtPathWithMapKeys(t *testing.T) {
+	type TestCase struct {
+		source any
+		path   string
+	}
+	testCases := map[string]TestCase{
+		"named string":     {map[Color]any{"red": "found"}, "red"},
+		"int":              {map[int]any{-3: "found"}, `"-3"`},
+		"uint8":            {map[uint8]any{200: "found"}, `"200"`},
+		"float":            {map[float64]any{1.5: "found"}, `"1.5"`},
+		"bool":             {map[bool]any{true: "found"}, "true"},
+		"text unmarshaler": {map[Point]any{{1, 2}: "found"}, `"1,2"`},
+		"any with string":  {map[any]any{"a": "found", 1: "other"}, "a"},
+		"any with int":     {map[any]any{1: "found", "x": "other"}, `"1"`},
+		"nested any":       {map[any]any{"items": map[any]any{2: map[any]any{"type": "found"}}}, `items/"2"/type`},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var value reflect.Value
+			if err := GetValueAtPath(&tc.source, *MustParse(tc.path), &value); err != nil {
+				t.Fatalf("error getting value: %s", err)
+			} else if value.Interface() != "found" {
+				t.Fatalf(`expected "found", but got %v`, value)
+			}
+		})
+	}
+}
+
+func TestGetValueAtPathWithMapKeysAndError(t *testing.T) {
+	testCases := map[string]any{
+		"not an int": map[int]any{1: "a"},
+		"overflow":   map[uint8]any{1: "a"},
+		"struct key": map[struct{ A int }]any{{1}: "a"},
+	}
+	paths := map[string]string{"not an int": "abc", "overflow": `"300"`, "struct key": "a"}
+	for name, source := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var value reflect.Value
+			err := GetValueAtPath(&source, *MustParse(paths[name]), &value)
+			var conversionError *golimorphError.KeyConversionError
+			if !errors.Is(err, golimorphError.ErrKeyConversion) || !errors.As(err, &conversionError) {
+				t.Fatalf("expected a KeyConversionError, but got %v", err)
+			} else if !strings.Contains(err.Error(), "to map key of type") {
+				t.Fatalf("expected a descriptive error, but got %s", err)
+			}
+		})
+	}
+}
+
+func TestSetValueAtPathWithMapKeys(t *testing.T) {
+	source := map[int]map[Color]any{}
+	if err := SetValueAtPath(&source, *MustParse(`"4"/red`), 1); err != nil {
+		t.Fatalf("error setting value: %s", err)
+	} else if expected := (map[int]map[Color]any{4: {"red": 1}}); !reflect.DeepEqual(source, expected) {
+		t.Fatalf("expected %v, but got %v", expected, source)
+	}
+	if err := DeleteAtPath(&source, *MustParse(`"4"`)); err != nil {
+		t.Fatalf("error deleting value: %s", err)
+	} else if len(source) != 0 {
+		t.Fatalf("expected the map to be empty, but got %v", source)
+	}
+}
@@ -1,6 +1,8 @@
 package objectpath
 
 import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
 	"reflect"
 	"testing"
 )
@@ -34,6 +36,8 @@ type Duck struct {
 func TestGetValueAtPath(t *testing.T) {
 	var testCases = []TestCase{
 		{map[string]any{"foo": map[string]any{"bar": map[string]any{"test": int64(123)}}}, "foo/bar/test", int64(123)},
+		{map[string]any{"items": []any{map[string]any{"test": int64(1)}, map[string]any{"test": int64(2)}}}, `items/"1"/test`, int64(2)},
+		{struct{ Items [2]int64 }{[2]int64{3, 4}}, `items/"0"`, int64(3)},
 	}
 
 	for _, tc := range testCases {
@@ -58,6 +62,27 @@ func TestGetValueAtPath(t *testing.T) {
 	}
 }
 
+func TestGetValueAtPath_IndexNotFound(t *testing.T) {
+	var testCases = []struct {
+		inputObject any
+		inputPath   string
+	}{
+		{[]any{1, 2}, `"2"`},
+		{[]any{1, 2}, `"-1"`},
+		{[]any{1, 2}, `first`},
+		{[2]int{1, 2}, `"2"`},
+	}
+
+	for _, tc := range testCases {
+		var outVal reflect.Value
+		input := tc.inputObject
+		err := GetValueAtPath(&input, *MustParse(tc.inputPath), &outVal)
+		if !errors.Is(err, golimorphError.ErrPathNotFound) {
+			t.Errorf("expected a PathNotFoundError for path %s in %v, but got %v", tc.inputPath, tc.inputObject, err)
+		}
+	}
+}
+
 func TestAssignTypeAtPath(t *testing.T) {
 	var testCases = []TestCase{
 		{Animal{Name: "horse", Specifics: map[string]any{}}, "Specifics", Horse{}},
@@ -90,7 +115,10 @@ func TestAssignTypeAtPath(t *testing.T) {
 
 func TestAssignTypeAtPathWithError(t *testing.T) {
 	var testCases = []ErrorTestCase{
-		{true, "Specifics", reflect.TypeOf(0), `cannot get value at path ["Specifics"]: value at path index 0 is neither a map nor struct`},
+		{true, "Specifics", reflect.TypeOf(0), `cannot get value at path [Specifics]: value at path index 0 is neither a map nor struct`},
+		{Animal{}, "Name", reflect.TypeOf(0), `cannot assign type int to value of type string at path [Name]`},
+		{[]any{}, `"0"`, reflect.TypeOf(0), `cannot get value at path ["0"]: index [0] out of range in slice at path index 0`},
+		{map[string]any{"Specifics": 0}, "Specifics", reflect.TypeOf(0), `cannot assign type int to value at path [Specifics]: the value cannot be set, e.g. because it is stored in a map or an interface`},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,215 @@
+package objectpath
+
+import (
+	"fmt"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"strconv"
+)
+
+// SetValueAtPath sets the value at the given path in source to value. Missing values along the path are created like
+// in EnsurePath. A nil value sets the zero value. The source must be a pointer.
+func SetValueAtPath(source any, path ObjectPath, value any) error {
+	return modifyAtPath(source, path, true, DefaultLimits, func(target reflect.Value) error {
+		newValue := reflect.ValueOf(value)
+		if !newValue.IsValid() {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		} else if !newValue.Type().AssignableTo(target.Type()) {
+			return &golimorphError.TypeNotAssignableError{Path: path.String(), Type: newValue.Type(), TargetType: target.Type()}
+		}
+		target.Set(newValue)
+		return nil
+	})
+}
+
+// DeleteAtPath deletes the value at the given path in source. Map entries are deleted, slice elements are removed
+// and all other values, e.g. struct fields and array elements, are set to their zero value. The source must be a
+// pointer.
+func DeleteAtPath(source any, path ObjectPath) error {
+	if path.getLength() == 0 {
+		return fmt.Errorf(`cannot delete at path [%s]: the path references the source itself`, path.String())
+	}
+	parentPath := ObjectPath{path.elements[:path.getLength()-1], path.isAbsolute}
+	return modifyAtPath(source, parentPath, false, DefaultLimits, func(parent reflect.Value) error {
+		return deleteChild(parent, path, path.getLength()-1)
+	})
+}
+
+// deleteChild deletes the child of the settable parent that is described by the element at index i of path
+func deleteChild(parent reflect.Value, path ObjectPath, i int) error {
+	element := path.elements[i]
+	switch parent.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if parent.IsNil() {
+			return &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Invalid}
+		} else if parent.Kind() == reflect.Ptr {
+			return deleteChild(parent.Elem(), path, i)
+		}
+		copied := reflect.New(parent.Elem().Type()).Elem()
+		copied.Set(parent.Elem())
+		if err := deleteChild(copied, path, i); err != nil {
+			return err
+		}
+		parent.Set(copied)
+		return nil
+	case reflect.Map:
+		key, err := mapKey(parent, path, i)
+		if err != nil {
+			return err
+		} else if !parent.MapIndex(key).IsValid() {
+			return &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Map}
+		}
+		parent.SetMapIndex(key, reflect.Value{})
+		return nil
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(element.name)
+		if err != nil || index < 0 || index >= parent.Len() {
+			return &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: parent.Kind()}
+		} else if parent.Kind() == reflect.Array {
+			parent.Index(index).Set(reflect.Zero(parent.Type().Elem()))
+			return nil
+		}
+		parent.Set(reflect.AppendSlice(parent.Slice(0, index), parent.Slice(index+1, parent.Len())))
+		return nil
+	case reflect.Struct:
+		index, ok := lookupField(parent.Type(), element.name)
+		if !ok {
+			return &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Struct}
+		}
+		field, err := parent.FieldByIndexErr(index)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	default:
+		return &golimorphError.NotTraversableError{Path: path.String(), Index: i, Kind: parent.Kind()}
+	}
+}
+
+// EnsurePath returns the value at the given path in source like GetValueAtPath, but creates missing values on the
+// way: nil pointers are allocated, slices are grown to contain the requested index and missing map entries are
+// added. Missing values of type any become a map[string]any. The value is returned as a reflect.Value in out. Since
+// map entries cannot be set, the value of a map entry is a copy. The source must be a pointer. Slices are grown to at
+// most DefaultLimits.MaxElements elements.
+func EnsurePath(source any, path ObjectPath, out *reflect.Value) error {
+	return EnsurePathWithLimits(source, path, DefaultLimits, out)
+}
+
+// EnsurePathWithLimits is like EnsurePath, but uses the given limits instead of the DefaultLimits. If a slice would
+// have to grow beyond limits.MaxElements elements, e.g. for a huge index taken from a payload, an
+// error.LimitExceededError is returned instead of allocating it.
+func EnsurePathWithLimits(source any, path ObjectPath, limits Limits, out *reflect.Value) error {
+	return modifyAtPath(source, path, true, limits, func(value reflect.Value) error {
+		*out = value
+		return nil
+	})
+}
+
+// modifyAtPath calls fn with the settable value at the given path in source. If create is true, missing values
+// along the path are created, otherwise an error.PathNotFoundError is returned. Slices are grown to at most
+// limits.MaxElements elements.
+func modifyAtPath(source any, path ObjectPath, create bool, limits Limits, fn func(value reflect.Value) error) error {
+	value := reflect.ValueOf(source)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf(`cannot modify value at path [%s]: source is not a pointer`, path.String())
+	}
+	for _, element := range path.elements {
+		if element.IsWildcard() || element.IsUpwardsReference() || element.IsFilter() {
+			return fmt.Errorf(`cannot modify value at path [%s]: the path must only contain identifiers`, path.String())
+		}
+	}
+	if err := checkPathLength(path, limits.MaxPathLength); err != nil {
+		return err
+	}
+	return modify(value.Elem(), path, 0, create, limits, fn)
+}
+
+// modify calls fn with the settable value at the path below value, starting at the element at index i of path.
+// Values inside of interfaces and maps cannot be set, so they are copied into a settable value that is written back
+// after the modification.
+func modify(value reflect.Value, path ObjectPath, i int, create bool, limits Limits, fn func(value reflect.Value) error) error {
+	if i == path.getLength() {
+		return fn(value)
+	}
+	element := path.elements[i]
+	if element.elementType == ElementTypeSelfReference {
+		return modify(value, path, i+1, create, limits, fn)
+	}
+
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			if !create {
+				return &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Invalid}
+			}
+			newMap := reflect.ValueOf(map[string]any{})
+			if !newMap.Type().AssignableTo(value.Type()) {
+				return &golimorphError.TypeNotAssignableError{Path: path.String(), Type: newMap.Type(), TargetType: value.Type()}
+			}
+			value.Set(newMap)
+		}
+		copied := reflect.New(value.Elem().Type()).Elem()
+		copied.Set(value.Elem())
+		if err := modify(copied, path, i, create, limits, fn); err != nil {
+			return err
+		}
+		value.Set(copied)
+		return nil
+	case reflect.Ptr:
+		if value.IsNil() {
+			if !create {
+				return &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Invalid}
+			}
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return modify(value.Elem(), path, i, create, limits, fn)
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(element.name)
+		if err == nil && index >= value.Len() && create && value.Kind() == reflect.Slice {
+			if limits.MaxElements > 0 && index >= limits.MaxElements {
+				return &golimorphError.LimitExceededError{Path: path.String(), Limit: golimorphError.LimitElements, Max: limits.MaxElements}
+			}
+			grown := reflect.MakeSlice(value.Type(), index+1, index+1)
+			reflect.Copy(grown, value)
+			value.Set(grown)
+		}
+		if err != nil || index < 0 || index >= value.Len() {
+			return &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: value.Kind()}
+		}
+		return modify(value.Index(index), path, i+1, create, limits, fn)
+	case reflect.Map:
+		key, err := mapKey(value, path, i)
+		if err != nil {
+			return err
+		}
+		child := value.MapIndex(key)
+		if !child.IsValid() && !create {
+			return &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Map}
+		} else if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		copied := reflect.New(value.Type().Elem()).Elem()
+		if child.IsValid() {
+			copied.Set(child)
+		}
+		if err := modify(copied, path, i+1, create, limits, fn); err != nil {
+			return err
+		}
+		value.SetMapIndex(key, copied)
+		return nil
+	case reflect.Struct:
+		index, ok := lookupField(value.Type(), element.name)
+		if !ok {
+			return &golimorphError.PathNotFoundError{Path: path.String(), Index: i, Element: element.name, Kind: reflect.Struct}
+		}
+		field, err := value.FieldByIndexErr(index)
+		if err != nil {
+			return err
+		}
+		return modify(field, path, i+1, create, limits, fn)
+	default:
+		return &golimorphError.NotTraversableError{Path: path.String(), Index: i, Kind: value.Kind()}
+	}
+}
@@ -0,0 +1,125 @@
+package objectpath
+
+import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"testing"
+)
+
+func TestSetValueAtPath(t *testing.T) {
+	type Inner struct{ Value any }
+	type Outer struct {
+		Inner *Inner
+		Items []Inner
+	}
+	type TestCase struct {
+		source   any
+		path     string
+		value    any
+		expected any
+	}
+	testCases := map[string]TestCase{
+		"map":              {map[string]any{"a": map[string]any{"b": 1}}, "a/b", 2, map[string]any{"a": map[string]any{"b": 2}}},
+		"missing map":      {map[string]any{}, "a/b", 2, map[string]any{"a": map[string]any{"b": 2}}},
+		"nil pointer":      {Outer{}, "inner/value", "x", Outer{Inner: &Inner{"x"}}},
+		"grown slice":      {Outer{}, `items/"1"/value`, "x", Outer{Items: []Inner{{}, {"x"}}}},
+		"slice in map":     {map[string]any{"items": []any{1, 2}}, `items/"1"`, 3, map[string]any{"items": []any{1, 3}}},
+		"struct in map":    {map[string]Inner{"a": {1}}, "a/value", 2, map[string]Inner{"a": {2}}},
+		"nil value":        {map[string]any{"a": 1}, "a", nil, map[string]any{"a": nil}},
+		"typed map entry":  {map[string]int{}, "a", 1, map[string]int{"a": 1}},
+		"replace the root": {map[string]any{"a": 1}, "", map[string]any{"b": 2}, map[string]any{"b": 2}},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+			source := reflect.New(reflect.TypeOf(tc.source))
+			source.Elem().Set(reflect.ValueOf(tc.source))
+			if err := SetValueAtPath(source.Interface(), *MustParse(tc.path), tc.value); err != nil {
+				t.Fatalf("error setting value: %s", err)
+			}
+
+			// Assert
+			if actual := source.Elem().Interface(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %#v, but got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSetValueAtPathWithError(t *testing.T) {
+	source := map[string]any{"a": "text", "b": map[string]int{}}
+	if err := SetValueAtPath(&source, *MustParse("a/b"), 1); !errors.Is(err, golimorphError.ErrNotTraversable) {
+		t.Fatalf("expected a NotTraversableError, but got %v", err)
+	}
+	if err := SetValueAtPath(&source, *MustParse("b/c"), "text"); !errors.Is(err, golimorphError.ErrTypeNotAssignable) {
+		t.Fatalf("expected a TypeNotAssignableError, but got %v", err)
+	}
+	if err := SetValueAtPath(&source, *MustParse("*/c"), 1); err == nil {
+		t.Fatalf("expected an error for a wildcard path, but got none")
+	}
+}
+
+func TestDeleteAtPath(t *testing.T) {
+	type Inner struct{ Value any }
+	type TestCase struct {
+		source   any
+		path     string
+		expected any
+	}
+	testCases := map[string]TestCase{
+		"map entry":     {map[string]any{"a": map[string]any{"b": 1, "c": 2}}, "a/b", map[string]any{"a": map[string]any{"c": 2}}},
+		"slice element": {map[string]any{"items": []any{1, 2, 3}}, `items/"1"`, map[string]any{"items": []any{1, 3}}},
+		"struct field":  {[]Inner{{1}}, `"0"/value`, []Inner{{}}},
+		"array element": {[2]any{1, 2}, `"1"`, [2]any{1, nil}},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+			source := reflect.New(reflect.TypeOf(tc.source))
+			source.Elem().Set(reflect.ValueOf(tc.source))
+			if err := DeleteAtPath(source.Interface(), *MustParse(tc.path)); err != nil {
+				t.Fatalf("error deleting value: %s", err)
+			}
+
+			// Assert
+			if actual := source.Elem().Interface(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %#v, but got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDeleteAtPathWithError(t *testing.T) {
+	source := map[string]any{"a": map[string]any{}}
+	for _, path := range []string{"a/b", "b/c"} {
+		if err := DeleteAtPath(&source, *MustParse(path)); !errors.Is(err, golimorphError.ErrPathNotFound) {
+			t.Fatalf("expected a PathNotFoundError for path %s, but got %v", path, err)
+		}
+	}
+}
+
+func TestEnsurePath(t *testing.T) {
+	type Item struct{ Payload any }
+	var target struct {
+		Items []*Item
+		Extra map[string]any
+	}
+
+	var value reflect.Value
+	if err := EnsurePath(&target, *MustParse(`items/"2"/payload`), &value); err != nil {
+		t.Fatalf("error ensuring path: %s", err)
+	} else if len(target.Items) != 3 || target.Items[2] == nil {
+		t.Fatalf("expected the third item to be created, but got %v", target.Items)
+	} else if !value.CanSet() {
+		t.Fatalf("expected the value to be settable")
+	}
+
+	if err := EnsurePath(&target, *MustParse("extra/a/b"), &value); err != nil {
+		t.Fatalf("error ensuring path: %s", err)
+	} else if expected := map[string]any{"a": map[string]any{"b": nil}}; !reflect.DeepEqual(target.Extra, expected) {
+		t.Fatalf("expected extra to be %v, but got %v", expected, target.Extra)
+	}
+}
@@ -0,0 +1,47 @@
+package objectpath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is the error that is returned if a path string cannot be parsed
+type ParseError struct {
+	// Input is the string that was parsed
+	Input string
+	// Offset is the index of the offending rune in Input. It is the length of Input if the string ended unexpectedly.
+	Offset int
+	// Expected are the tokens that would have been valid at Offset, e.g. [/] or [letter]
+	Expected []string
+	// Message describes the error
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return e.Message
+}
+
+// Pretty returns a multi-line description of the error that shows the input with a caret under the offending rune,
+// e.g. for a path in a configuration file:
+//
+//	invalid path: unexpected character [#] at index 2. A non-enclosed path may only contain letters, digits, underscores and hyphens
+//	  fo#/bar
+//	    ^ expected one of [letter digit _ - / []
+func (e *ParseError) Pretty() string {
+	var s strings.Builder
+	s.WriteString("invalid path: ")
+	s.WriteString(e.Message)
+	s.WriteString("\n  ")
+	s.WriteString(e.Input)
+	s.WriteString("\n  ")
+	s.WriteString(strings.Repeat(" ", e.Offset))
+	s.WriteString("^")
+	switch len(e.Expected) {
+	case 0:
+	case 1:
+		s.WriteString(fmt.Sprintf(" expected %s", e.Expected[0]))
+	default:
+		s.WriteString(fmt.Sprintf(" expected one of %v", e.Expected))
+	}
+	return s.String()
+}
@@ -0,0 +1,51 @@
+package objectpath
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	type TestCase struct {
+		input    string
+		offset   int
+		expected []string
+	}
+	testCases := []TestCase{
+		{"fo#/bar", 2, []string{"letter", "digit", "_", "-", "/", "["}},
+		{`foo/"bar`, 8, []string{`"`}},
+		{`"foo"bar`, 5, []string{"/", "["}},
+		{"foo/.../bar", 4, []string{".", ".."}},
+		{"items/-a", 6, []string{"letter", "_", `"`, "@", "$"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			var parseError *ParseError
+			if !errors.As(err, &parseError) {
+				t.Fatalf("expected a ParseError, but got %v", err)
+			} else if parseError.Input != tc.input {
+				t.Errorf(`expected input "%s", but got "%s"`, tc.input, parseError.Input)
+			} else if parseError.Offset != tc.offset {
+				t.Errorf("expected offset %d, but got %d", tc.offset, parseError.Offset)
+			} else if !reflect.DeepEqual(parseError.Expected, tc.expected) {
+				t.Errorf("expected tokens %v, but got %v", tc.expected, parseError.Expected)
+			}
+		})
+	}
+}
+
+func TestParseError_Pretty(t *testing.T) {
+	_, err := Parse("fo#/bar")
+	var parseError *ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf("expected a ParseError, but got %v", err)
+	}
+	expected := "invalid path: unexpected character [#] at index 2. A non-enclosed path may only contain letters, digits, underscores and hyphens\n" +
+		"  fo#/bar\n" +
+		"    ^ expected one of [letter digit _ - / []"
+	if pretty := parseError.Pretty(); pretty != expected {
+		t.Fatalf("expected\n%s\nbut got\n%s", expected, pretty)
+	}
+}
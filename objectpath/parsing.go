@@ -2,6 +2,7 @@ package objectpath
 
 import (
 	"fmt"
+	"strings"
 	"unicode"
 )
 
@@ -21,6 +22,10 @@ const (
 	ParsingStateSlash
 	// The ParsingStateDot ParsingState is the state of the parser when parsing a dot, e.g. in `../bar`.
 	ParsingStateDot
+	// The ParsingStateStar ParsingState is the state of the parser when parsing a wildcard, e.g. in `items/*/type`.
+	ParsingStateStar
+	// The ParsingStateFilter ParsingState is the state of the parser when parsing a filter, e.g. `[?(@.name=="kind")]` in `attributes[?(@.name=="kind")]/value`.
+	ParsingStateFilter
 )
 
 // Context contains the current ParsingState, input string, and other information needed for parsing.
@@ -30,6 +35,13 @@ type Context struct {
 	state     ParsingState
 	i         int
 	reprocess bool
+	sigils    string
+	input     string
+}
+
+// newError returns a ParseError for the rune at offset. The message is formatted like fmt.Sprintf.
+func (ctx *Context) newError(offset int, expected []string, format string, args ...any) *ParseError {
+	return &ParseError{Input: ctx.input, Offset: offset, Expected: expected, Message: fmt.Sprintf(format, args...)}
 }
 
 // hasChar returns true if the current index is within the bounds of the string.
@@ -45,7 +57,7 @@ func (ctx *Context) hasNextChar() bool {
 // assertChar returns an error if the current character is not the expected character.
 func (ctx *Context) assertChar(expected rune) error {
 	if c := ctx.chars[ctx.i]; c != expected {
-		return fmt.Errorf(`unexpected character [%c] at index %d. Expected [%c]`, c, ctx.i, expected)
+		return ctx.newError(ctx.i, []string{string(expected)}, `unexpected character [%c] at index %d. Expected [%c]`, c, ctx.i, expected)
 	}
 	return nil
 }
@@ -53,7 +65,7 @@ func (ctx *Context) assertChar(expected rune) error {
 // assertNextChar returns an error if the next character is not the expected character.
 func (ctx *Context) assertNextChar(expected rune) error {
 	if !ctx.hasNextChar() {
-		return fmt.Errorf(`unexpected end of string after %d runes. Expected [%c]`, len(ctx.chars), expected)
+		return ctx.newError(len(ctx.chars), []string{string(expected)}, `unexpected end of string after %d runes. Expected [%c]`, len(ctx.chars), expected)
 	}
 	ctx.i++
 	return ctx.assertChar(expected)
@@ -90,7 +102,7 @@ var charParsingFunctions = []func(ctx *Context) error{
 		switch c {
 		case '/':
 			if ctx.path.isCurrentPartEmpty() {
-				return fmt.Errorf(`empty path element provided at index %d. Empty elements must be enclosed in quotes, e.g. /""/data`, ctx.i)
+				return ctx.newError(ctx.i, []string{"name"}, `empty path element provided at index %d. Empty elements must be enclosed in quotes, e.g. /""/data`, ctx.i)
 			}
 			ctx.state = ParsingStateSlash
 			ctx.reprocess = true
@@ -101,11 +113,25 @@ var charParsingFunctions = []func(ctx *Context) error{
 				ctx.reprocess = true
 				return nil
 			}
+		case '*':
+			if ctx.path.isCurrentPartEmpty() {
+				ctx.state = ParsingStateStar
+				ctx.reprocess = true
+				return nil
+			}
+		case '[':
+			if ctx.path.isCurrentPartEmpty() {
+				ctx.state = ParsingStateFilter
+			} else {
+				ctx.state = ParsingStateSlash
+			}
+			ctx.reprocess = true
+			return nil
 		}
-		if ctx.path.isCurrentPartEmpty() && !unicode.IsLetter(c) {
-			return fmt.Errorf(`unexpected character [%c] at index %d. A non-enclosed path must start with a letter`, c, ctx.i)
-		} else if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
-			return fmt.Errorf(`unexpected character [%c] at index %d. A non-enclosed path may only contain letters and digits`, c, ctx.i)
+		if ctx.path.isCurrentPartEmpty() && !isNameStart(c, ctx.sigils) {
+			return ctx.newError(ctx.i, nameStartTokens(ctx.sigils), `unexpected character [%c] at index %d. A non-enclosed path must start with a letter, an underscore or one of [%s]`, c, ctx.i, ctx.sigils)
+		} else if !ctx.path.isCurrentPartEmpty() && !isNamePart(c) {
+			return ctx.newError(ctx.i, []string{"letter", "digit", "_", "-", "/", "["}, `unexpected character [%c] at index %d. A non-enclosed path may only contain letters, digits, underscores and hyphens`, c, ctx.i)
 		}
 		ctx.path.appendCharToCurrentElement(c)
 		return nil
@@ -130,8 +156,13 @@ var charParsingFunctions = []func(ctx *Context) error{
 	},
 
 	ParsingStateSlash: func(ctx *Context) error {
-		if err := ctx.assertChar('/'); err != nil {
-			return err
+		if ctx.currentChar() == '[' {
+			ctx.path.appendElement()
+			ctx.state = ParsingStateFilter
+			ctx.reprocess = true
+			return nil
+		} else if c := ctx.currentChar(); c != '/' {
+			return ctx.newError(ctx.i, []string{"/", "["}, `unexpected character [%c] at index %d. Expected [/]`, c, ctx.i)
 		}
 		ctx.state = ParsingStateBeginning
 		return nil
@@ -143,7 +174,7 @@ var charParsingFunctions = []func(ctx *Context) error{
 		case '.':
 			ctx.path.appendCharToCurrentElement(c)
 			if e.name == "..." {
-				return fmt.Errorf(`invalid path element [...] at index %d. Only [.] or [..] allowed`, ctx.i-2)
+				return ctx.newError(ctx.i-2, []string{".", ".."}, `invalid path element [...] at index %d. Only [.] or [..] allowed`, ctx.i-2)
 			} else if !ctx.hasNextChar() {
 				ctx.chars = append(ctx.chars, '/') // append slash to end of string to parse last element
 			}
@@ -157,16 +188,99 @@ var charParsingFunctions = []func(ctx *Context) error{
 			ctx.state = ParsingStateSlash
 			ctx.reprocess = true
 		default:
-			return fmt.Errorf(`unexpected character [%c] at index %d. Expected either [.] or [/]`, c, ctx.i)
+			return ctx.newError(ctx.i, []string{".", "/"}, `unexpected character [%c] at index %d. Expected either [.] or [/]`, c, ctx.i)
+		}
+		return nil
+	},
+
+	ParsingStateStar: func(ctx *Context) error {
+		e := ctx.path.currentElement()
+		switch c := ctx.currentChar(); c {
+		case '*':
+			ctx.path.appendCharToCurrentElement(c)
+			if e.name == "***" {
+				return ctx.newError(ctx.i-2, []string{"*", "**"}, `invalid path element [***] at index %d. Only [*] or [**] allowed`, ctx.i-2)
+			}
+		case '/':
+			switch e.name {
+			case "*":
+				e.elementType = ElementTypeWildcard
+			case "**":
+				e.elementType = ElementTypeRecursiveWildcard
+			}
+			ctx.state = ParsingStateSlash
+			ctx.reprocess = true
+		default:
+			return ctx.newError(ctx.i, []string{"*", "/"}, `unexpected character [%c] at index %d. Expected either [*] or [/]`, c, ctx.i)
+		}
+		return nil
+	},
+
+	ParsingStateFilter: func(ctx *Context) error {
+		start := ctx.i
+		end := skipFilterExpression(ctx.chars, start+1)
+		if end >= len(ctx.chars) {
+			return ctx.newError(len(ctx.chars), []string{"]"}, `unexpected end of string after %d runes. Expected []]`, len(ctx.chars))
+		}
+		expression := string(ctx.chars[start+1 : end])
+		if _, err := compileFilter(expression); err != nil {
+			return ctx.newError(start, []string{"filter ?(@.path == value)"}, `invalid filter at index %d: %s`, start, err)
 		}
+		e := ctx.path.currentElement()
+		e.name = expression
+		e.elementType = ElementTypeFilter
+		ctx.i = end
+		ctx.state = ParsingStateSlash
 		return nil
 	},
 }
 
-// ParsePathString parses a string into a slice of Element.
+// skipFilterExpression returns the index of the closing bracket of the filter expression that starts at index i.
+// Brackets within quoted strings are ignored. If there is no closing bracket, len(chars) is returned.
+func skipFilterExpression(chars []rune, i int) int {
+	quoted := false
+	for ; i < len(chars); i++ {
+		switch c := chars[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == ']':
+			return i
+		}
+	}
+	return i
+}
+
+// isNameStart returns true if c may start a non-enclosed name, i.e. it is a letter, an underscore or one of sigils
+func isNameStart(c rune, sigils string) bool {
+	return unicode.IsLetter(c) || c == '_' || strings.ContainsRune(sigils, c)
+}
+
+// nameStartTokens returns the tokens that may start a non-enclosed name, see isNameStart
+func nameStartTokens(sigils string) []string {
+	tokens := []string{"letter", "_", `"`}
+	for _, sigil := range sigils {
+		tokens = append(tokens, string(sigil))
+	}
+	return tokens
+}
+
+// isNamePart returns true if c may be part of a non-enclosed name after its first character
+func isNamePart(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-'
+}
+
+// ParsePathString parses a string into a slice of Element. Non-enclosed names may start with the DefaultSigils.
+// Syntax errors are returned as a *ParseError.
 func ParsePathString(s string, path *Elements) error {
+	return parsePathString(s, path, DefaultSigils)
+}
+
+// parsePathString parses a string into a slice of Element. Non-enclosed names may start with one of sigils.
+func parsePathString(s string, path *Elements, sigils string) error {
 	*path = make(Elements, 0) // reset path
-	ctx := Context{[]rune(s), path, ParsingStateBeginning, 0, false}
+	ctx := Context{[]rune(s), path, ParsingStateBeginning, 0, false, sigils, s}
 
 	// check if path is an absolute path
 	if !ctx.hasChar() {
@@ -195,9 +309,9 @@ func ParsePathString(s string, path *Elements) error {
 	// check if current element is completely parsed
 	if ctx.state == ParsingStateEnclosedIdentifier {
 		return ctx.assertNextChar('"') // misuse methode to throw error
-	} else if ctx.state == ParsingStateDot {
+	} else if ctx.state == ParsingStateDot || ctx.state == ParsingStateStar {
 		ctx.chars = append(ctx.chars, '/') // append slash to end of string to parse last element
-		if err := charParsingFunctions[ParsingStateDot](&ctx); err != nil {
+		if err := charParsingFunctions[ctx.state](&ctx); err != nil {
 			return err
 		}
 	}
@@ -32,10 +32,24 @@ func TestParsePathString(t *testing.T) {
 		{"foo/", []Element{{"foo", ElementTypeIdentifier}}, nil},
 		{".", []Element{ElementSelfReference}, nil},
 		{"", []Element{}, nil},
+		{"items/*/type", []Element{{"items", ElementTypeIdentifier}, ElementWildcard, {"type", ElementTypeIdentifier}}, nil},
+		{"/**/payload", []Element{ElementRoot, ElementRecursiveWildcard, {"payload", ElementTypeIdentifier}}, nil},
+		{`items/"*"`, []Element{{"items", ElementTypeIdentifier}, {"*", ElementTypeIdentifier}}, nil},
+		{"*", []Element{ElementWildcard}, nil},
+		{"event_type/content-type/@type/$kind/_id", PathElementsFromStringArray([]string{"event_type", "content-type", "@type", "$kind", "_id"}), nil},
+		{`attributes[?(@.name=="kind")]/value`, []Element{{"attributes", ElementTypeIdentifier}, {`?(@.name=="kind")`, ElementTypeFilter}, {"value", ElementTypeIdentifier}}, nil},
+		{`"attributes"[?(@.name=="a]")]`, []Element{{"attributes", ElementTypeIdentifier}, {`?(@.name=="a]")`, ElementTypeFilter}}, nil},
+		{`[?(@)]/value`, []Element{{`?(@)`, ElementTypeFilter}, {"value", ElementTypeIdentifier}}, nil},
 		{`foo/"bar`, nil, errors.New(`unexpected end of string after 8 runes. Expected ["]`)},
-		{`fo#`, nil, errors.New(`unexpected character [#] at index 2. A non-enclosed path may only contain letters and digits`)},
+		{`fo#`, nil, errors.New(`unexpected character [#] at index 2. A non-enclosed path may only contain letters, digits, underscores and hyphens`)},
 		{`fo//bar`, nil, errors.New(`empty path element provided at index 3. Empty elements must be enclosed in quotes, e.g. /""/data`)},
 		{`.../foo`, nil, errors.New(`invalid path element [...] at index 0. Only [.] or [..] allowed`)},
+		{`items/***`, nil, errors.New(`invalid path element [***] at index 6. Only [*] or [**] allowed`)},
+		{`-a`, nil, errors.New(`unexpected character [-] at index 0. A non-enclosed path must start with a letter, an underscore or one of [@$]`)},
+		{`a@b`, nil, errors.New(`unexpected character [@] at index 1. A non-enclosed path may only contain letters, digits, underscores and hyphens`)},
+		{`items/*a`, nil, errors.New(`unexpected character [a] at index 7. Expected either [*] or [/]`)},
+		{`items[?(@.a=="b")`, nil, errors.New(`unexpected end of string after 17 runes. Expected []]`)},
+		{`items[?(@.a<1)]`, nil, errors.New(`invalid filter at index 5: filter [?(@.a<1)] contains an unknown operator. Expected either [==] or [!=]`)},
 	}
 
 	for _, tc := range testCases {
@@ -67,7 +81,8 @@ func TestParsePathString(t *testing.T) {
 }
 
 func TestNewObjectPathFromStringWithStringMethod(t *testing.T) {
-	testCases := []string{`/"foo"/"bar"`, `"foo"/""/./..`}
+	testCases := []string{`/foo/bar`, `foo/""/./..`, `/items/*/**/"*"`, `attributes/[?(@.name=="kind")]/value`,
+		`@type/$kind/event_type/content-type`, `"3"/"-a"/"a.b"/"a\"b\\c"/"."`}
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf(`ParsePathString with input "%s"`, tc), func(t *testing.T) {
 			err, path := NewObjectPathFromString(tc)
@@ -85,3 +100,45 @@ func TestNewObjectPathFromStringWithStringMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectPath_JSONPointer(t *testing.T) {
+	testCases := map[string]string{
+		`/items/"3"/payload`: "/items/3/payload",
+		`/"a/b"/"m~n"`:       "/a~1b/m~0n",
+		`/""`:                "/",
+		"/":                  "",
+	}
+	for input, expected := range testCases {
+		t.Run(input, func(t *testing.T) {
+			if pointer := MustParse(input).JSONPointer(); pointer != expected {
+				t.Errorf(`expected "%s", but got "%s"`, expected, pointer)
+			}
+		})
+	}
+}
+
+func TestParseWithOptions(t *testing.T) {
+	options := ParseOptions{Sigils: "%"}
+	if path, err := ParseWithOptions("%type/name", options); err != nil {
+		t.Fatalf("error parsing path: %s", err)
+	} else if name := path.elements[0].name; name != "%type" {
+		t.Fatalf(`expected first element to be "%%type", but got "%s"`, name)
+	}
+	if _, err := ParseWithOptions("@type", options); err == nil {
+		t.Fatalf("expected an error for a sigil that is not configured, but got none")
+	}
+	if _, err := Parse("%type"); err == nil {
+		t.Fatalf("expected an error for a sigil that is not a default sigil, but got none")
+	}
+}
+
+func TestParseWithOptions_String(t *testing.T) {
+	path, err := ParseWithOptions("%type/name", ParseOptions{Sigils: "%"})
+	if err != nil {
+		t.Fatalf("error parsing path: %s", err)
+	} else if s := path.String(); s != `"%type"/name` {
+		t.Fatalf(`expected path to be formatted as "%%type"/name, but got %s`, s)
+	} else if parsed, err := Parse(path.String()); err != nil || !parsed.IsEqualTo(path) {
+		t.Fatalf("expected the formatted path to parse to the same path, but got %v (%v)", parsed, err)
+	}
+}
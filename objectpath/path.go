@@ -3,8 +3,12 @@ package objectpath
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
+// jsonPointerEscaper escapes the special characters of a JSON Pointer reference token
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
 // Elements is a slice of Element
 type Elements []Element
 
@@ -40,20 +44,75 @@ type ObjectPath struct {
 }
 
 // NewObjectPathFromString creates a new ObjectPath from a string
+//
+// Deprecated: Use Parse, which returns the values in conventional order.
 func NewObjectPathFromString(s string) (error, *ObjectPath) {
+	path, err := Parse(s)
+	return err, path
+}
+
+// DefaultSigils are the characters that may start a non-enclosed name in addition to letters and underscores, e.g.
+// the @ of @type. They are used by Parse and String.
+const DefaultSigils = "@$"
+
+// ParseOptions configure the grammar that is accepted by ParseWithOptions
+type ParseOptions struct {
+	// Sigils are the characters that may start a non-enclosed name in addition to letters and underscores. They
+	// are not kept by the path, see ObjectPath.String.
+	Sigils string
+	// MaxPathLength is the maximum number of elements of the path. Zero disables the limit.
+	MaxPathLength int
+}
+
+// Parse creates a new ObjectPath from a string, e.g. `/foo/"bar"/../baz`. A leading slash makes the path absolute.
+// Non-enclosed names consist of letters, digits, underscores and hyphens and start with a letter, an underscore or
+// one of the DefaultSigils, e.g. event_type, content-type or @type. Any other name must be enclosed in quotes.
+// Strings starting with # are parsed as a JSON Pointer in URI fragment representation, e.g. `#/items/0/type`,
+// see ParseJSONPointer. Only this representation is recognized as a JSON Pointer: a plain pointer like /items/0/type
+// is parsed with the native syntax, where the unquoted index 0 is a syntax error. Use `#/items/0/type` or
+// ParseJSONPointer instead. Syntax errors of all other strings are returned as a *ParseError.
+func Parse(s string) (*ObjectPath, error) {
+	return ParseWithOptions(s, ParseOptions{Sigils: DefaultSigils, MaxPathLength: DefaultLimits.MaxPathLength})
+}
+
+// ParseWithOptions is like Parse, but uses the given options instead of the defaults. Paths with more elements
+// than options.MaxPathLength are rejected with an error.LimitExceededError.
+func ParseWithOptions(s string, options ParseOptions) (*ObjectPath, error) {
+	if strings.HasPrefix(s, "#") {
+		path, err := parseJSONPointerFragment(s)
+		if err != nil {
+			return nil, err
+		} else if err := checkPathLength(*path, options.MaxPathLength); err != nil {
+			return nil, err
+		}
+		return path, nil
+	}
 	var path ObjectPath
-	if err := ParsePathString(s, &path.elements); err != nil {
-		return err, nil
+	if err := parsePathString(s, &path.elements, options.Sigils); err != nil {
+		return nil, err
 	}
 
 	// check if path is absolute
 	if path.getLength() > 0 && path.elements[0].IsRootElement() {
 		path.isAbsolute = true
 		if err := path.DeleteAt(0, 1); err != nil {
-			return err, nil
+			return nil, err
 		}
 	}
-	return nil, &path
+	if err := checkPathLength(path, options.MaxPathLength); err != nil {
+		return nil, err
+	}
+	return &path, nil
+}
+
+// MustParse is like Parse but panics if the path cannot be parsed. It simplifies the initialization of
+// package level paths.
+func MustParse(s string) *ObjectPath {
+	path, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return path
 }
 
 // NewSelfReferencePath creates a new ObjectPath with a single self reference element. The path is relative.
@@ -61,6 +120,12 @@ func NewSelfReferencePath() *ObjectPath {
 	return &ObjectPath{Elements{ElementSelfReference}, false}
 }
 
+// NewRootPath creates a new absolute ObjectPath without elements. It references the root of an object.
+func NewRootPath() *ObjectPath {
+	return &ObjectPath{Elements{}, true}
+}
+
+// NewEmptyPath creates a new relative ObjectPath without elements.
 func NewEmptyPath() *ObjectPath {
 	return &ObjectPath{Elements{}, false}
 }
@@ -110,8 +175,8 @@ func (p *ObjectPath) ToAbsolutePath(referencePath *ObjectPath) error {
 		return errors.New("the given reference path must be absolute")
 	}
 
-	// concatenate the paths
-	p.elements = append(referencePath.elements, p.elements...)
+	// concatenate the paths into a new slice, so that neither path shares the elements of the other
+	p.elements = append(append(Elements{}, referencePath.elements...), p.elements...)
 	p.isAbsolute = true
 	if err := p.Normalize(); err != nil {
 		return fmt.Errorf("error normalizing path: %s", err)
@@ -156,12 +221,30 @@ func (p *ObjectPath) IsEqualTo(other *ObjectPath) bool {
 	return true
 }
 
+// Elements returns a copy of the elements of the path
+func (p *ObjectPath) Elements() Elements {
+	return append(Elements{}, p.elements...)
+}
+
 // getLength returns the length of the path
 func (p *ObjectPath) getLength() int {
 	return len(p.elements)
 }
 
-// String returns the string representation of the path. This string would lead to the same path when parsed again.
+// JSONPointer returns the path as an RFC 6901 JSON Pointer, e.g. /items/3/payload. The path should be normalized.
+func (p *ObjectPath) JSONPointer() string {
+	var s strings.Builder
+	for _, part := range p.elements {
+		s.WriteByte('/')
+		s.WriteString(jsonPointerEscaper.Replace(part.name))
+	}
+	return s.String()
+}
+
+// String returns the string representation of the path. This string would lead to the same path when parsed again
+// with Parse. Names are only enclosed in quotes if Parse requires it. The path does not remember the sigils it was
+// parsed with, so names are quoted according to the DefaultSigils: a name starting with a custom sigil is enclosed,
+// while a name starting with one of the DefaultSigils is not and only parses again if the options permit it.
 func (p *ObjectPath) String() string {
 	var s string
 	if p.isAbsolute {
@@ -172,12 +255,31 @@ func (p *ObjectPath) String() string {
 			s += "/"
 		}
 		if part.elementType == ElementTypeIdentifier {
-			s += string('"')
-			s += part.name
-			s += string('"')
+			s += quoteName(part.name)
+		} else if part.elementType == ElementTypeFilter {
+			s += "[" + part.name + "]"
 		} else {
 			s += part.name
 		}
 	}
 	return s
 }
+
+// quoteName returns the name as it must be written in a path, i.e. enclosed in quotes with escaped quotes and
+// backslashes, unless it is a valid non-enclosed name
+func quoteName(name string) string {
+	needsQuotes := name == ""
+	for i, c := range name {
+		if (i == 0 && !isNameStart(c, DefaultSigils)) || (i > 0 && !isNamePart(c)) {
+			needsQuotes = true
+			break
+		}
+	}
+	if !needsQuotes {
+		return name
+	}
+	return `"` + nameEscaper.Replace(name) + `"`
+}
+
+// nameEscaper escapes the characters that have a special meaning within quoted names
+var nameEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
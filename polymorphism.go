@@ -1,11 +1,170 @@
 package golymorph
 
 import (
+	"fmt"
+	golimorphError "github.com/SoulKa/golymorph/error"
 	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
 )
 
 // Polymorphism is the base struct for all polymorphism mappers. It contains the target path to assign the new type to.
 type Polymorphism struct {
-	// TargetPath is the path to the object to assign the new type to
+	// TargetPath is the path to the object to assign the new type to. It may contain wildcards to assign a type to
+	// every matching object, see objectpath.FindAllAtPath.
 	TargetPath objectpath.ObjectPath
+
+	// SourcePath is the path to the polymorphic object in the source, if it differs from the TargetPath, e.g. because
+	// a field is renamed by a struct tag. If it is the zero value, the TargetPath is used. If the paths contain
+	// wildcards, both must have the same wildcards at the same positions.
+	SourcePath objectpath.ObjectPath
+
+	// Limits restrict the traversal of the source, i.e. the lookup of discriminators and rule values and the search
+	// for the objects of paths with wildcards or filters. If nil, the objectpath.DefaultLimits are used.
+	Limits *objectpath.Limits
+}
+
+// targetPathHolder is implemented by all polymorphism mappers that embed Polymorphism
+type targetPathHolder interface {
+	targetPath() objectpath.ObjectPath
+}
+
+// targetPath returns the TargetPath of the Polymorphism
+func (p *Polymorphism) targetPath() objectpath.ObjectPath {
+	return p.TargetPath
+}
+
+// sourcePath returns the SourcePath of the Polymorphism, or the TargetPath if the SourcePath is not set
+func (p *Polymorphism) sourcePath() objectpath.ObjectPath {
+	if p.SourcePath.IsRelativePath() && len(p.SourcePath.Elements()) == 0 {
+		return p.TargetPath
+	}
+	return p.SourcePath
+}
+
+// limits returns the Limits of the Polymorphism, or the objectpath.DefaultLimits if they are not set
+func (p *Polymorphism) limits() objectpath.Limits {
+	if p.Limits == nil {
+		return objectpath.DefaultLimits
+	}
+	return *p.Limits
+}
+
+// assignTargetTypes assigns a new type to every location in target that corresponds to a match of the source path in
+// source. The type of each location is determined by resolve, which receives the concrete source path and the
+// concrete target path of the location. Missing slice elements and pointers of the target are created. All failing
+// locations are returned as an error.AggregateError.
+func (p *Polymorphism) assignTargetTypes(source any, target any, resolve func(sourcePath, targetPath objectpath.ObjectPath) (reflect.Type, error)) error {
+	sourcePath := p.sourcePath()
+	matches, err := objectpath.FindAllAtPathWithLimits(source, sourcePath, p.limits())
+	if err != nil {
+		return err
+	}
+	var aggregateError golimorphError.AggregateError
+	for _, match := range matches {
+		targetPath, err := translateMatch(p.TargetPath, sourcePath, match.Path)
+		if err != nil {
+			return err
+		}
+		var newType reflect.Type
+		err = checkMapKeys(source, target, match.Path, *targetPath, p.limits())
+		if err == nil {
+			newType, err = resolve(match.Path, *targetPath)
+		}
+		if err == nil {
+			err = assignTypeAt(target, *targetPath, newType, p.limits())
+		}
+		if err != nil {
+			aggregateError.Errors = append(aggregateError.Errors, &golimorphError.ResolutionError{Location: targetPath.JSONPointer(), Err: err})
+		}
+	}
+	if len(aggregateError.Errors) > 0 {
+		return &aggregateError
+	}
+	return nil
+}
+
+// translateMatch returns the concrete target path that corresponds to the concrete path of a match of sourcePattern.
+// The elements of targetPattern are used, except for its wildcards and filters, which are replaced by the concrete
+// elements of the match.
+func translateMatch(targetPattern objectpath.ObjectPath, sourcePattern objectpath.ObjectPath, match objectpath.ObjectPath) (*objectpath.ObjectPath, error) {
+	if targetPattern.IsEqualTo(&sourcePattern) {
+		return &match, nil
+	}
+	targetElements := targetPattern.Elements()
+	sourceElements := sourcePattern.Elements()
+	matchElements := match.Elements()
+	if len(targetElements) != len(sourceElements) || len(sourceElements) != len(matchElements) {
+		return nil, fmt.Errorf("cannot translate source path [%s] to target path [%s]: the paths must have the same length", sourcePattern.String(), targetPattern.String())
+	}
+	var elements []any
+	if targetPattern.IsAbsolutePath() {
+		elements = append(elements, objectpath.ElementRoot)
+	}
+	for i, element := range targetElements {
+		sourceIsConcrete := sourceElements[i].Type() == objectpath.ElementTypeIdentifier
+		targetIsConcrete := element.Type() == objectpath.ElementTypeIdentifier
+		if sourceIsConcrete != targetIsConcrete {
+			return nil, fmt.Errorf("cannot translate source path [%s] to target path [%s]: the wildcards and filters must be at the same positions", sourcePattern.String(), targetPattern.String())
+		} else if targetIsConcrete {
+			elements = append(elements, element)
+		} else {
+			elements = append(elements, matchElements[i])
+		}
+	}
+	return objectpath.Of(elements...), nil
+}
+
+// checkMapKeys returns an error if a key of a map in source would be used as the index of a slice or array in target,
+// i.e. if the container of an element of the concrete source path match is a map, while the container of the same
+// element of the concrete targetPath is a slice or array. Map keys are chosen freely by the source, e.g. "20000000",
+// so they must not decide the length of a slice. The containers of targetPath are created like in EnsurePath.
+func checkMapKeys(source any, target any, match objectpath.ObjectPath, targetPath objectpath.ObjectPath, limits objectpath.Limits) error {
+
+	// collect the containers of the elements, so that they can be checked from the root on
+	elements := match.Elements()
+	sourceParents := make([]objectpath.ObjectPath, len(elements))
+	targetParents := make([]objectpath.ObjectPath, len(elements))
+	sourceParent, targetParent := match.Parent(), targetPath.Parent()
+	for i := len(elements) - 1; i >= 0; i-- {
+		sourceParents[i], targetParents[i] = *sourceParent, *targetParent
+		sourceParent, targetParent = sourceParent.Parent(), targetParent.Parent()
+	}
+
+	for i, element := range elements {
+		var sourceValue, targetValue reflect.Value
+		if err := objectpath.GetValueAtPathWithLimits(source, sourceParents[i], limits, &sourceValue); err != nil {
+			return err
+		} else if containerKind(sourceValue) != reflect.Map {
+			continue
+		} else if err := objectpath.EnsurePathWithLimits(target, targetParents[i], limits, &targetValue); err != nil {
+			return err
+		} else if kind := containerKind(targetValue); kind == reflect.Slice || kind == reflect.Array {
+			return fmt.Errorf("cannot assign type at path [%s]: the key [%s] of a map in the source cannot be used as index of a %s in the target", targetPath.String(), element.Name(), kind)
+		}
+	}
+	return nil
+}
+
+// containerKind returns the kind of the value behind the pointers and interfaces of value. For nil pointers, the
+// kind of the referenced type is returned.
+func containerKind(value reflect.Value) reflect.Kind {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() && value.Kind() == reflect.Ptr {
+			return value.Type().Elem().Kind()
+		} else if value.IsNil() {
+			return reflect.Invalid
+		}
+		value = value.Elem()
+	}
+	return value.Kind()
+}
+
+// assignTypeAt assigns newType to the value at the concrete targetPath in target. Missing values are created within
+// the given limits.
+func assignTypeAt(target any, targetPath objectpath.ObjectPath, newType reflect.Type, limits objectpath.Limits) error {
+	var value reflect.Value
+	if err := objectpath.EnsurePathWithLimits(target, targetPath, limits, &value); err != nil {
+		return err
+	}
+	return objectpath.AssignTypeAtPath(target, targetPath, newType)
 }
@@ -1,82 +1,164 @@
 package golymorph
 
 import (
+	"errors"
 	"github.com/SoulKa/golymorph/objectpath"
-	"strings"
+	"reflect"
 )
 
 type polymorphismBuilderBase struct {
 	targetPath objectpath.ObjectPath
+	sourcePath objectpath.ObjectPath
+	limits     *objectpath.Limits
 	errors     []error
 }
 
-type polymorphismBuilderEmpty interface {
+// PolymorphismBuilder is the entry point of the fluent polymorphism builder returned by NewPolymorphismBuilder.
+type PolymorphismBuilder interface {
 	// DefineTypeAt defines the target path of the polymorphism. This is the path where the polymorphism
-	// will be applied, i.e. where the new type is set. For valid paths see objectpath.NewObjectPathFromString.
-	DefineTypeAt(targetPath string) polymorphismBuilderStrategySelector
+	// will be applied, i.e. where the new type is set. Relative paths are relative to the root of the
+	// target. For valid paths, see objectpath.Parse.
+	DefineTypeAt(targetPath string) PolymorphismStrategySelector
 }
 
-type polymorphismBuilderStrategySelector interface {
+// PolymorphismStrategySelector selects the strategy that is used to determine the new type.
+type PolymorphismStrategySelector interface {
+	// WithSourcePath defines the path of the polymorphic object in the source, if it differs from the target path,
+	// e.g. because a field is renamed by a struct tag. Relative discriminator paths are then relative to the source
+	// path. Relative source paths are relative to the root of the source, see objectpath.Parse.
+	WithSourcePath(sourcePath string) PolymorphismStrategySelector
+
+	// WithSourcePathFromTags derives the source path from the target path and the names in the given struct tag of
+	// the target, e.g. "mapstructure" for UnmarshalJSON, see TranslatePath.
+	WithSourcePathFromTags(target any, tagName string) PolymorphismStrategySelector
+
+	// WithLimits restricts the traversal of the source, see Polymorphism.Limits. By default, the
+	// objectpath.DefaultLimits are used. The limits do not apply to the paths of the builder, which are parsed with
+	// objectpath.Parse and its default limits.
+	WithLimits(limits objectpath.Limits) PolymorphismStrategySelector
+
 	// UsingRule defines a rule that is used to determine the new type. The rules are applied in the
 	// order they are defined. The first rule that matches is used to determine the new type.
-	UsingRule(rule Rule) polymorphismBuilderRuleAdder
+	UsingRule(rule Rule) PolymorphismRuleAdder
 
 	// UsingTypeMap defines a type map that is used to determine the new type. The type map is applied
-	UsingTypeMap(typeMap TypeMap) polymorphismBuilderDiscriminatorKeyDefiner
+	UsingTypeMap(typeMap TypeMap) PolymorphismDiscriminatorDefiner
 }
 
-type polymorphismBuilderRuleAdder interface {
+// PolymorphismRuleAdder adds further rules to a rule based polymorphism or builds it.
+type PolymorphismRuleAdder interface {
 	// UsingRule defines a rule that is used to determine the new type. The rules are applied in the
 	// order they are defined. The first rule that matches is used to determine the new type.
-	UsingRule(rule Rule) polymorphismBuilderRuleAdder
+	UsingRule(rule Rule) PolymorphismRuleAdder
 
 	// Build creates a new TypeResolver that can be used to resolve a polymorphic type.
+	//
+	// Deprecated: Use BuildResolver, which returns the values in conventional order.
 	Build() (error, TypeResolver)
+
+	// BuildResolver creates a new TypeResolver that can be used to resolve a polymorphic type.
+	BuildResolver() (TypeResolver, error)
 }
 
-type polymorphismBuilderDiscriminatorKeyDefiner interface {
+// PolymorphismDiscriminatorDefiner defines where the discriminator of a type map polymorphism is located.
+type PolymorphismDiscriminatorDefiner interface {
 	// WithDiscriminatorAt defines the path to the discriminator key. The discriminator key is used to
 	// determine the new type. The value of the discriminator key is used to lookup the new type in the
-	// type map.
-	WithDiscriminatorAt(discriminatorKey string) polymorphismBuilderFinalizer
+	// type map. For valid paths, see objectpath.Parse.
+	WithDiscriminatorAt(discriminatorKey string) PolymorphismFinalizer
 }
 
-type polymorphismBuilderFinalizer interface {
+// PolymorphismFinalizer builds a fully defined polymorphism.
+type PolymorphismFinalizer interface {
+	// UsingDiscriminatorMethod defines the name of the method of the variants that returns their discriminator
+	// value. It is used to obtain the discriminator value when marshalling, see TypeMapPolymorphism.DiscriminatorOf.
+	UsingDiscriminatorMethod(methodName string) PolymorphismFinalizer
+
 	// Build creates a new TypeResolver that can be used to resolve a polymorphic type.
+	//
+	// Deprecated: Use BuildResolver, which returns the values in conventional order.
 	Build() (error, TypeResolver)
+
+	// BuildResolver creates a new TypeResolver that can be used to resolve a polymorphic type.
+	BuildResolver() (TypeResolver, error)
 }
 
 // NewPolymorphismBuilder creates a new polymorphism builder that is used in a human readable way to create a polymorphism.
 // It only allows a valid combination of rules and type maps.
-func NewPolymorphismBuilder() polymorphismBuilderEmpty {
-	return &polymorphismBuilderBase{*objectpath.NewSelfReferencePath(), []error{}}
+func NewPolymorphismBuilder() PolymorphismBuilder {
+	return &polymorphismBuilderBase{*objectpath.NewSelfReferencePath(), objectpath.ObjectPath{}, nil, []error{}}
+}
+
+// clone returns a copy of the builder state. Every step of the builder works on a copy, so that a stored builder can
+// be reused without affecting the results of previous steps.
+func (b *polymorphismBuilderBase) clone() *polymorphismBuilderBase {
+	c := *b
+	c.errors = append([]error{}, b.errors...)
+	return &c
+}
+
+func (b *polymorphismBuilderBase) DefineTypeAt(targetPath string) PolymorphismStrategySelector {
+	c := b.clone()
+
+	// parse target path and make it absolute
+	if path, err := objectpath.Parse(targetPath); err != nil {
+		c.errors = append(c.errors, err)
+	} else if err := path.ToAbsolutePath(objectpath.NewRootPath()); err != nil {
+		c.errors = append(c.errors, err)
+	} else {
+		c.targetPath = *path
+	}
+	return c
 }
 
-func (b *polymorphismBuilderBase) DefineTypeAt(targetPath string) polymorphismBuilderStrategySelector {
-	// make target path absolute
-	if !strings.HasPrefix(targetPath, "/") {
-		targetPath = "/" + targetPath
+func (b *polymorphismBuilderBase) WithSourcePath(sourcePath string) PolymorphismStrategySelector {
+	c := b.clone()
+	if path, err := objectpath.Parse(sourcePath); err != nil {
+		c.errors = append(c.errors, err)
+	} else if err := path.ToAbsolutePath(objectpath.NewRootPath()); err != nil {
+		c.errors = append(c.errors, err)
+	} else {
+		c.sourcePath = *path
 	}
+	return c
+}
 
-	// parse target path
-	if err, path := objectpath.NewObjectPathFromString(targetPath); err != nil {
-		b.errors = append(b.errors, err)
+func (b *polymorphismBuilderBase) WithSourcePathFromTags(target any, tagName string) PolymorphismStrategySelector {
+	c := b.clone()
+	if target == nil {
+		c.errors = append(c.errors, errors.New("cannot derive the source path from the tags of nil"))
+	} else if path, err := TranslatePath(c.targetPath, reflect.TypeOf(target), tagName); err != nil {
+		c.errors = append(c.errors, err)
 	} else {
-		b.targetPath = *path
+		c.sourcePath = *path
+	}
+	return c
+}
+
+func (b *polymorphismBuilderBase) WithLimits(limits objectpath.Limits) PolymorphismStrategySelector {
+	c := b.clone()
+	c.limits = &limits
+	return c
+}
+
+// resolvedSourcePath returns the source path, or the target path if no source path is defined
+func (b *polymorphismBuilderBase) resolvedSourcePath() *objectpath.ObjectPath {
+	if b.sourcePath.IsAbsolutePath() {
+		return &b.sourcePath
 	}
-	return b
+	return &b.targetPath
 }
 
-func (b *polymorphismBuilderBase) UsingRule(rule Rule) polymorphismBuilderRuleAdder {
+func (b *polymorphismBuilderBase) UsingRule(rule Rule) PolymorphismRuleAdder {
 	return &polymorphismRuleBuilder{
-		polymorphismBuilderBase: *b,
+		polymorphismBuilderBase: *b.clone(),
 		rules:                   []Rule{rule},
 	}
 }
 
-func (b *polymorphismBuilderBase) UsingTypeMap(typeMap TypeMap) polymorphismBuilderDiscriminatorKeyDefiner {
+func (b *polymorphismBuilderBase) UsingTypeMap(typeMap TypeMap) PolymorphismDiscriminatorDefiner {
 	return &polymorphismTypeMapBuilder{
-		polymorphismBuilderBase: *b,
+		polymorphismBuilderBase: *b.clone(),
 		typeMap:                 typeMap,
 	}
 }
@@ -1,6 +1,7 @@
 package golymorph
 
 import (
+	"github.com/SoulKa/golymorph/objectpath"
 	"reflect"
 	"testing"
 )
@@ -70,3 +71,127 @@ func HasErrors(t *testing.T, errors []error) bool {
 	}
 	return len(errors) > 0
 }
+
+func TestPolymorphismBuilder_BuildResolver(t *testing.T) {
+
+	// Arrange
+	typeMap := TypeMap{
+		"test": reflect.TypeOf(int64(0)),
+	}
+	var builder PolymorphismFinalizer = NewPolymorphismBuilder().
+		DefineTypeAt("foo/bar").
+		UsingTypeMap(typeMap).
+		WithDiscriminatorAt("discriminator")
+
+	// Act
+	polymorphism, err := builder.BuildResolver()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no errors, but got %s", err)
+	} else if polymorphism == nil {
+		t.Fatalf("expected polymorphism to not be nil")
+	}
+}
+
+func TestPolymorphismBuilder_BuildResolverWithError(t *testing.T) {
+
+	// Act
+	polymorphism, err := NewPolymorphismBuilder().
+		DefineTypeAt("foo/#").
+		UsingRule(Rule{}).
+		BuildResolver()
+
+	// Assert
+	if err == nil {
+		t.Fatalf("expected an error, but got none")
+	} else if polymorphism != nil {
+		t.Fatalf("expected polymorphism to be nil, but got %+v", polymorphism)
+	}
+}
+
+func TestMust(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Must to panic")
+		}
+	}()
+	Must(NewRuleBuilder().
+		WhenValueAt("foo/#").
+		IsEqualTo("test").
+		ThenAssignType(reflect.TypeOf(int64(0))).
+		BuildRule())
+}
+
+func TestPolymorphismBuilder_WithJSONPointers(t *testing.T) {
+
+	// Arrange
+	resolver, err := NewPolymorphismBuilder().
+		DefineTypeAt("#/specifics").
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("#/specifics/type").
+		BuildResolver()
+	if err != nil {
+		t.Fatalf("expected no errors, but got %s", err)
+	}
+
+	// Act
+	animal, err := Unmarshal[Animal](resolver, []byte(testCases[0].inputJson))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error unmarshalling animal: %s", err)
+	} else if !reflect.DeepEqual(animal, testCases[0].output) {
+		t.Fatalf("expected animal to be %+v, but got %+v", testCases[0].output, animal)
+	}
+}
+
+func TestPolymorphismBuilder_WithPlainJSONPointer(t *testing.T) {
+	build := func(targetPath string) error {
+		_, err := NewPolymorphismBuilder().
+			DefineTypeAt(targetPath).
+			UsingTypeMap(animalTypeMap).
+			WithDiscriminatorAt("type").
+			BuildResolver()
+		return err
+	}
+	if err := build("/animals/0/specifics"); err == nil {
+		t.Fatalf("expected an error for a plain JSON pointer, but got none")
+	} else if err := build("#/animals/0/specifics"); err != nil {
+		t.Fatalf("expected no errors for a JSON pointer fragment, but got %s", err)
+	}
+}
+
+func TestPolymorphismBuilder_Reuse(t *testing.T) {
+
+	// Arrange
+	selector := NewPolymorphismBuilder().DefineTypeAt("/payload/inner")
+	typeMapBuilder := selector.UsingTypeMap(animalTypeMap)
+	horseRule := Rule{*objectpath.MustParse("/kind"), func(v any) bool { return v == "horse" }, reflect.TypeOf(Horse{})}
+	duckRule := Rule{*objectpath.MustParse("/kind"), func(v any) bool { return v == "duck" }, reflect.TypeOf(Duck{})}
+	ruleAdder := selector.UsingRule(horseRule)
+
+	// Act
+	first := Must(typeMapBuilder.WithDiscriminatorAt("../type").BuildResolver()).(*TypeMapPolymorphism)
+	second := Must(typeMapBuilder.WithDiscriminatorAt("kind").BuildResolver()).(*TypeMapPolymorphism)
+	withDuck := Must(ruleAdder.UsingRule(duckRule).BuildResolver()).(*RulePolymorphism)
+	withoutDuck := Must(ruleAdder.BuildResolver()).(*RulePolymorphism)
+	_, err := selector.WithSourcePath("1invalid").UsingTypeMap(animalTypeMap).WithDiscriminatorAt("type").BuildResolver()
+
+	// Assert
+	if s := first.DiscriminatorPath.String(); s != "/payload/type" {
+		t.Errorf("expected first discriminator path to be /payload/type, but got %s", s)
+	} else if s := first.TargetPath.String(); s != "/payload/inner" {
+		t.Errorf("expected first target path to be /payload/inner, but got %s", s)
+	} else if s := second.DiscriminatorPath.String(); s != "/payload/inner/kind" {
+		t.Errorf("expected second discriminator path to be /payload/inner/kind, but got %s", s)
+	}
+	if len(withDuck.Rules) != 2 || len(withoutDuck.Rules) != 1 {
+		t.Errorf("expected 2 and 1 rules, but got %d and %d", len(withDuck.Rules), len(withoutDuck.Rules))
+	}
+	if err == nil {
+		t.Errorf("expected an error for an invalid source path, but got none")
+	} else if _, err := selector.UsingTypeMap(animalTypeMap).WithDiscriminatorAt("type").BuildResolver(); err != nil {
+		t.Errorf("expected the error of a derived builder not to affect the stored builder, but got %s", err)
+	}
+}
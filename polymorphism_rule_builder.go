@@ -9,17 +9,26 @@ type polymorphismRuleBuilder struct {
 	rules []Rule
 }
 
-func (b *polymorphismRuleBuilder) UsingRule(rule Rule) polymorphismBuilderRuleAdder {
-	b.rules = append(b.rules, rule)
-	return b
+func (b *polymorphismRuleBuilder) UsingRule(rule Rule) PolymorphismRuleAdder {
+	return &polymorphismRuleBuilder{
+		polymorphismBuilderBase: *b.polymorphismBuilderBase.clone(),
+		rules:                   append(append([]Rule{}, b.rules...), rule),
+	}
 }
 
 func (b *polymorphismRuleBuilder) Build() (error, TypeResolver) {
+	resolver, err := b.BuildResolver()
+	return err, resolver
+}
+
+func (b *polymorphismRuleBuilder) BuildResolver() (TypeResolver, error) {
 	if len(b.errors) > 0 {
-		return errors.Join(b.errors...), nil
+		return nil, errors.Join(b.errors...)
 	}
-	return nil, &RulePolymorphism{
+	return &RulePolymorphism{
 		Polymorphism{
-			TargetPath: b.targetPath},
-		b.rules}
+			TargetPath: b.targetPath,
+			SourcePath: b.sourcePath,
+			Limits:     b.limits},
+		b.rules}, nil
 }
@@ -7,28 +7,51 @@ import (
 
 type polymorphismTypeMapBuilder struct {
 	polymorphismBuilderBase
-	typeMap           TypeMap
-	discriminatorPath objectpath.ObjectPath
+	typeMap             TypeMap
+	discriminatorPath   objectpath.ObjectPath
+	discriminatorMethod string
 }
 
-func (b *polymorphismTypeMapBuilder) WithDiscriminatorAt(discriminatorKey string) polymorphismBuilderFinalizer {
-	if err, path := objectpath.NewObjectPathFromString(discriminatorKey); err != nil {
-		b.errors = append(b.errors, err)
-	} else if err := path.ToAbsolutePath(&b.targetPath); err != nil {
-		b.errors = append(b.errors, err)
+// clone returns a copy of the builder state, see polymorphismBuilderBase.clone
+func (b *polymorphismTypeMapBuilder) clone() *polymorphismTypeMapBuilder {
+	c := *b
+	c.polymorphismBuilderBase = *b.polymorphismBuilderBase.clone()
+	return &c
+}
+
+func (b *polymorphismTypeMapBuilder) WithDiscriminatorAt(discriminatorKey string) PolymorphismFinalizer {
+	c := b.clone()
+	if path, err := objectpath.Parse(discriminatorKey); err != nil {
+		c.errors = append(c.errors, err)
+	} else if err := path.ToAbsolutePath(c.resolvedSourcePath()); err != nil {
+		c.errors = append(c.errors, err)
 	} else {
-		b.discriminatorPath = *path
+		c.discriminatorPath = *path
 	}
-	return b
+	return c
+}
+
+func (b *polymorphismTypeMapBuilder) UsingDiscriminatorMethod(methodName string) PolymorphismFinalizer {
+	c := b.clone()
+	c.discriminatorMethod = methodName
+	return c
 }
 
 func (b *polymorphismTypeMapBuilder) Build() (error, TypeResolver) {
+	resolver, err := b.BuildResolver()
+	return err, resolver
+}
+
+func (b *polymorphismTypeMapBuilder) BuildResolver() (TypeResolver, error) {
 	if len(b.errors) > 0 {
-		return errors.Join(b.errors...), nil
+		return nil, errors.Join(b.errors...)
 	}
-	return nil, &TypeMapPolymorphism{
+	return &TypeMapPolymorphism{
 		Polymorphism: Polymorphism{
-			TargetPath: b.targetPath},
-		DiscriminatorPath: b.discriminatorPath,
-		TypeMap:           b.typeMap}
+			TargetPath: b.targetPath,
+			SourcePath: b.sourcePath,
+			Limits:     b.limits},
+		DiscriminatorPath:   b.discriminatorPath,
+		TypeMap:             b.typeMap,
+		DiscriminatorMethod: b.discriminatorMethod}, nil
 }
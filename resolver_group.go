@@ -0,0 +1,59 @@
+package golymorph
+
+import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+)
+
+// ResolverGroup is a TypeResolver that applies multiple TypeResolvers to the same source and target, e.g. for a
+// parent type with multiple polymorphic fields. The resolvers are applied in order.
+type ResolverGroup struct {
+	// Resolvers are the TypeResolvers to apply
+	Resolvers []TypeResolver
+
+	// CollectErrors enables the error collection mode. Instead of returning at the first failing resolver, all
+	// resolvers are applied and an error.AggregateError containing the location of every failure is returned.
+	CollectErrors bool
+}
+
+// NewResolverGroup creates a new ResolverGroup that returns at the first failing resolver.
+func NewResolverGroup(resolvers ...TypeResolver) *ResolverGroup {
+	return &ResolverGroup{Resolvers: resolvers}
+}
+
+// NewCollectingResolverGroup creates a new ResolverGroup in error collection mode.
+func NewCollectingResolverGroup(resolvers ...TypeResolver) *ResolverGroup {
+	return &ResolverGroup{Resolvers: resolvers, CollectErrors: true}
+}
+
+func (g *ResolverGroup) AssignTargetType(source any, target any) error {
+	var aggregateError golimorphError.AggregateError
+	for _, resolver := range g.Resolvers {
+		err := resolver.AssignTargetType(source, target)
+		if err == nil {
+			continue
+		} else if !g.CollectErrors {
+			return err
+		}
+		collectError(&aggregateError, resolver, err)
+	}
+	if len(aggregateError.Errors) > 0 {
+		return &aggregateError
+	}
+	return nil
+}
+
+// collectError appends err, which was returned by resolver, to aggregateError. Nested aggregate errors are flattened.
+func collectError(aggregateError *golimorphError.AggregateError, resolver TypeResolver, err error) {
+	var nestedAggregateError *golimorphError.AggregateError
+	if errors.As(err, &nestedAggregateError) {
+		aggregateError.Errors = append(aggregateError.Errors, nestedAggregateError.Errors...)
+		return
+	}
+	var location string
+	if holder, ok := resolver.(targetPathHolder); ok {
+		targetPath := holder.targetPath()
+		location = targetPath.JSONPointer()
+	}
+	aggregateError.Errors = append(aggregateError.Errors, &golimorphError.ResolutionError{Location: location, Err: err})
+}
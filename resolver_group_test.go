@@ -0,0 +1,62 @@
+package golymorph
+
+import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"reflect"
+	"testing"
+)
+
+type Pair struct {
+	First  any
+	Second any
+}
+
+var pairResolvers = []TypeResolver{
+	Must(NewPolymorphismBuilder().DefineTypeAt("first").UsingTypeMap(animalTypeMap).WithDiscriminatorAt("type").BuildResolver()),
+	Must(NewPolymorphismBuilder().DefineTypeAt("second").UsingTypeMap(animalTypeMap).WithDiscriminatorAt("type").BuildResolver()),
+}
+
+func TestResolverGroup_AssignTargetType(t *testing.T) {
+
+	// Arrange
+	inputJson := `{ "first": { "type": "horse", "shoes": 4 }, "second": { "type": "duck", "feathers": 1000 } }`
+	expected := Pair{Horse{4}, Duck{1000}}
+
+	// Act
+	var actual Pair
+	if err := UnmarshalJSON(NewResolverGroup(pairResolvers...), []byte(inputJson), &actual); err != nil {
+		t.Fatalf("error unmarshalling pair: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected pair to be %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestResolverGroup_AssignTargetTypeWithError(t *testing.T) {
+	inputJson := []byte(`{ "first": { "type": "snake" }, "second": {} }`)
+
+	t.Run("fail fast", func(t *testing.T) {
+		var pair Pair
+		err := UnmarshalJSON(NewResolverGroup(pairResolvers...), inputJson, &pair)
+		if !errors.Is(err, golimorphError.ErrUnknownDiscriminator) || errors.Is(err, golimorphError.ErrDiscriminatorMissing) {
+			t.Fatalf("expected only the error of the first resolver, but got %v", err)
+		}
+	})
+
+	t.Run("collect errors", func(t *testing.T) {
+		var pair Pair
+		err := UnmarshalJSON(NewCollectingResolverGroup(NewCollectingResolverGroup(pairResolvers[0]), pairResolvers[1]), inputJson, &pair)
+
+		var aggregateError *golimorphError.AggregateError
+		if !errors.As(err, &aggregateError) {
+			t.Fatalf("expected an AggregateError, but got %v", err)
+		} else if locations := aggregateError.Locations(); !reflect.DeepEqual(locations, []string{"/first", "/second"}) {
+			t.Fatalf("expected locations to be [/first /second], but got %v", locations)
+		} else if !errors.Is(err, golimorphError.ErrUnknownDiscriminator) || !errors.Is(err, golimorphError.ErrDiscriminatorMissing) {
+			t.Fatalf("expected the errors of both resolvers, but got %v", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package golymorph
+
+import (
+	"errors"
+	"fmt"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+)
+
+// Router decodes JSON messages into T using a TypeResolver and dispatches them to the handler that is registered
+// for the concrete type of the polymorphic field. Register handlers with On before dispatching; registering is not
+// safe for concurrent use, dispatching is.
+type Router[T any] struct {
+	resolver   TypeResolver
+	targetPath objectpath.ObjectPath
+	handlers   map[reflect.Type]func(T, any) error
+	unhandled  func(T, any) error
+}
+
+// NewRouter creates a new Router that decodes messages into T using the given resolver. The resolver must have a
+// single target path without wildcards, i.e. be a TypeMapPolymorphism or RulePolymorphism. Messages with several
+// polymorphic fields, e.g. decoded with a ResolverGroup or a wildcard target path, cannot be dispatched by type and
+// are rejected with an error.
+func NewRouter[T any](resolver TypeResolver) (*Router[T], error) {
+	holder, ok := resolver.(targetPathHolder)
+	if !ok {
+		return nil, errors.New("cannot create router: the resolver has no single target path")
+	}
+	targetPath := holder.targetPath()
+	if targetPath.HasWildcards() {
+		return nil, fmt.Errorf("cannot create router: the target path [%s] of the resolver contains wildcards", targetPath.String())
+	}
+	return &Router[T]{
+		resolver:   resolver,
+		targetPath: targetPath,
+		handlers:   make(map[reflect.Type]func(T, any) error),
+		unhandled: func(_ T, value any) error {
+			return &golimorphError.UnhandledTypeError{Type: reflect.TypeOf(value)}
+		},
+	}, nil
+}
+
+// On registers handler for messages whose polymorphic field is of the concrete type P. A previously registered
+// handler for P is replaced. Since handlers are selected by the resolved concrete type, On panics if P is an
+// interface type.
+func On[P any, T any](r *Router[T], handler func(T, P) error) {
+	handlerType := reflect.TypeOf((*P)(nil)).Elem()
+	if handlerType.Kind() == reflect.Interface {
+		panic(fmt.Sprintf("cannot register handler for interface type %v: handlers are selected by concrete type", handlerType))
+	}
+	r.handlers[handlerType] = func(message T, value any) error {
+		return handler(message, value.(P))
+	}
+}
+
+// OnUnhandled sets the callback that is invoked for messages whose polymorphic field has a type without handler.
+// By default, an error.UnhandledTypeError is returned.
+func (r *Router[T]) OnUnhandled(callback func(message T, value any) error) {
+	r.unhandled = callback
+}
+
+// Dispatch decodes data into T and invokes the handler registered for the type of the polymorphic field.
+// The error of the handler is returned.
+func (r *Router[T]) Dispatch(data []byte) error {
+	// decode message
+	var message T
+	if err := UnmarshalJSON(r.resolver, data, &message); err != nil {
+		return err
+	}
+
+	// get the polymorphic value
+	var value reflect.Value
+	if err := objectpath.GetValueAtPath(&message, r.targetPath, &value); err != nil {
+		return err
+	}
+	var rawValue any
+	if value.IsValid() {
+		rawValue = value.Interface()
+	}
+
+	// invoke the handler
+	if handler, ok := r.handlers[reflect.TypeOf(rawValue)]; ok {
+		return handler(message, rawValue)
+	}
+	return r.unhandled(message, rawValue)
+}
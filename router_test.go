@@ -0,0 +1,90 @@
+package golymorph
+
+import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"testing"
+)
+
+func TestRouter_Dispatch(t *testing.T) {
+
+	// Arrange
+	var horses, ducks []string
+	router := Must(NewRouter[Animal](animalPolymorphism))
+	On(router, func(animal Animal, horse Horse) error {
+		horses = append(horses, animal.Name)
+		return nil
+	})
+	On(router, func(animal Animal, duck Duck) error {
+		ducks = append(ducks, animal.Name)
+		return nil
+	})
+
+	// Act
+	for _, tc := range testCases {
+		if err := router.Dispatch([]byte(tc.inputJson)); err != nil {
+			t.Fatalf("error dispatching %s: %s", tc.inputJson, err)
+		}
+	}
+
+	// Assert
+	if len(horses) != 1 || horses[0] != "horsey" {
+		t.Fatalf("expected the horse handler to be invoked for horsey, but got %v", horses)
+	} else if len(ducks) != 1 || ducks[0] != "ducky" {
+		t.Fatalf("expected the duck handler to be invoked for ducky, but got %v", ducks)
+	}
+}
+
+func TestRouter_DispatchUnhandled(t *testing.T) {
+	inputJson := []byte(testCases[0].inputJson)
+
+	t.Run("default", func(t *testing.T) {
+		router := Must(NewRouter[Animal](animalPolymorphism))
+		if err := router.Dispatch(inputJson); !errors.Is(err, golimorphError.ErrUnhandledType) {
+			t.Fatalf("expected an UnhandledTypeError, but got %v", err)
+		}
+	})
+
+	t.Run("callback", func(t *testing.T) {
+		var unhandled any
+		router := Must(NewRouter[Animal](animalPolymorphism))
+		router.OnUnhandled(func(animal Animal, value any) error {
+			unhandled = value
+			return nil
+		})
+		if err := router.Dispatch(inputJson); err != nil {
+			t.Fatalf("error dispatching: %s", err)
+		} else if unhandled != (Horse{4}) {
+			t.Fatalf("expected the callback to be invoked with the horse, but got %v", unhandled)
+		}
+	})
+}
+
+func TestRouter_OnInterface(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected registering a handler for an interface type to panic")
+		}
+	}()
+	router := Must(NewRouter[Animal](animalPolymorphism))
+	On(router, func(animal Animal, specifics any) error { return nil })
+}
+
+func TestNewRouterWithoutSingleTargetPath(t *testing.T) {
+	wildcardResolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("animals/*/specifics").
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+	resolvers := map[string]TypeResolver{
+		"wildcard": wildcardResolver,
+		"group":    NewResolverGroup(animalPolymorphism),
+	}
+	for name, resolver := range resolvers {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRouter[Herd](resolver); err == nil {
+				t.Fatalf("expected an error creating a router with a %s resolver, but got none", name)
+			}
+		})
+	}
+}
@@ -15,11 +15,18 @@ type Rule struct {
 	NewType reflect.Type
 }
 
-// Matches returns true if the source matches the rule.
+// Matches returns true if the source matches the rule. A null value matches no rule.
 func (r *Rule) Matches(source any) (error, bool) {
+	return r.matches(source, objectpath.DefaultLimits)
+}
+
+// matches is like Matches, but restricts the lookup of the value by limits
+func (r *Rule) matches(source any, limits objectpath.Limits) (error, bool) {
 	var comparatorValue reflect.Value
-	if err := objectpath.GetValueAtPath(source, r.ValuePath, &comparatorValue); err != nil {
+	if err := objectpath.GetValueAtPathWithLimits(source, r.ValuePath, limits, &comparatorValue); err != nil {
 		return err, false
+	} else if !comparatorValue.IsValid() {
+		return nil, false // null values match no rule
 	}
 	return nil, r.ComparatorFunction(comparatorValue.Interface())
 }
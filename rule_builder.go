@@ -1,6 +1,7 @@
 package golymorph
 
 import (
+	"errors"
 	"github.com/SoulKa/golymorph/objectpath"
 	"reflect"
 )
@@ -12,60 +13,92 @@ type ruleBuilder struct {
 	newType        reflect.Type
 }
 
-type ruleBuilderBase interface {
-	// WhenValueAt sets the path to the value in the source to compare.
-	WhenValueAt(valuePath string) ruleBuilderConditionSetter
+// RuleBuilder is the entry point of the fluent rule builder returned by NewRuleBuilder.
+type RuleBuilder interface {
+	// WhenValueAt sets the path to the value in the source to compare, see objectpath.Parse.
+	WhenValueAt(valuePath string) RuleConditionSetter
 }
 
-type ruleBuilderConditionSetter interface {
+// RuleConditionSetter sets the condition of the Rule.
+type RuleConditionSetter interface {
 	// IsEqualTo sets the value to compare to.
-	IsEqualTo(value any) ruleBuilderTypeAssigner
+	IsEqualTo(value any) RuleTypeAssigner
 
 	// Matches sets the function to use to compare the value at ValuePath to.
-	Matches(comparator func(any) bool) ruleBuilderTypeAssigner
+	Matches(comparator func(any) bool) RuleTypeAssigner
 }
 
-type ruleBuilderTypeAssigner interface {
+// RuleTypeAssigner sets the type that is assigned when the Rule matches.
+type RuleTypeAssigner interface {
 	// ThenAssignType sets the type to assign to the target if the rule matches.
-	ThenAssignType(newType reflect.Type) ruleBuilderFinalizer
+	ThenAssignType(newType reflect.Type) RuleFinalizer
 }
 
-type ruleBuilderFinalizer interface {
+// RuleFinalizer builds a fully defined Rule.
+type RuleFinalizer interface {
 	// Build builds the Rule and returns the errors encountered while building.
+	//
+	// Deprecated: Use BuildRule, which returns the values in conventional order.
 	Build() ([]error, Rule)
+
+	// BuildRule builds the Rule. All errors encountered while building are joined into a single error.
+	BuildRule() (Rule, error)
 }
 
-// NewRuleBuilder creates a new ruleBuilder. It enables a fluent interface for building a Rule.
-func NewRuleBuilder() ruleBuilderBase {
+// NewRuleBuilder creates a new RuleBuilder. It enables a fluent interface for building a Rule.
+func NewRuleBuilder() RuleBuilder {
 	return &ruleBuilder{}
 }
 
-func (b *ruleBuilder) WhenValueAt(valuePath string) ruleBuilderConditionSetter {
-	if err, path := objectpath.NewObjectPathFromString(valuePath); err != nil {
-		b.appendError(err)
+// clone returns a copy of the builder state. Every step of the builder works on a copy, so that a stored builder can
+// be reused without affecting the results of previous steps.
+func (b *ruleBuilder) clone() *ruleBuilder {
+	c := *b
+	c.errors = append([]error{}, b.errors...)
+	return &c
+}
+
+func (b *ruleBuilder) WhenValueAt(valuePath string) RuleConditionSetter {
+	c := b.clone()
+	if path, err := objectpath.Parse(valuePath); err != nil {
+		c.appendError(err)
 	} else {
-		b.valuePath = *path
+		c.valuePath = *path
 	}
-	return b
+	return c
 }
 
-func (b *ruleBuilder) IsEqualTo(value any) ruleBuilderTypeAssigner {
-	b.comparatorFunc = func(v any) bool { return v == value }
-	return b
+func (b *ruleBuilder) IsEqualTo(value any) RuleTypeAssigner {
+	c := b.clone()
+	c.comparatorFunc = func(v any) bool { return v == value }
+	return c
 }
 
-func (b *ruleBuilder) Matches(comparator func(any) bool) ruleBuilderTypeAssigner {
-	b.comparatorFunc = comparator
-	return b
+func (b *ruleBuilder) Matches(comparator func(any) bool) RuleTypeAssigner {
+	c := b.clone()
+	c.comparatorFunc = comparator
+	return c
 }
 
-func (b *ruleBuilder) ThenAssignType(newType reflect.Type) ruleBuilderFinalizer {
-	b.newType = newType
-	return b
+func (b *ruleBuilder) ThenAssignType(newType reflect.Type) RuleFinalizer {
+	c := b.clone()
+	c.newType = newType
+	return c
 }
 
 func (b *ruleBuilder) Build() ([]error, Rule) {
-	return b.errors, Rule{
+	return b.errors, b.rule()
+}
+
+func (b *ruleBuilder) BuildRule() (Rule, error) {
+	if len(b.errors) > 0 {
+		return Rule{}, errors.Join(b.errors...)
+	}
+	return b.rule(), nil
+}
+
+func (b *ruleBuilder) rule() Rule {
+	return Rule{
 		b.valuePath,
 		b.comparatorFunc,
 		b.newType,
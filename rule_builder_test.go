@@ -46,3 +46,36 @@ func TestRuleBuilder(t *testing.T) {
 	}
 
 }
+
+func TestRuleBuilder_BuildRule(t *testing.T) {
+
+	// Arrange
+	valuePath := objectpath.MustParse("foo/bar")
+	newType := reflect.TypeOf(int64(0))
+	expectedRule := Rule{ValuePath: *valuePath, NewType: newType}
+
+	// Act
+	rule, err := NewRuleBuilder().
+		WhenValueAt("foo/bar").
+		IsEqualTo("test").
+		ThenAssignType(newType).
+		BuildRule()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	}
+	if !rulesEqual(rule, expectedRule) {
+		t.Fatalf("expected rule to be %+v, but got %+v", expectedRule, rule)
+	}
+}
+
+func TestRuleBuilder_Reuse(t *testing.T) {
+	assigner := NewRuleBuilder().WhenValueAt("/kind").IsEqualTo("horse")
+	horseRule := Must(assigner.ThenAssignType(reflect.TypeOf(Horse{})).BuildRule())
+	duckRule := Must(assigner.ThenAssignType(reflect.TypeOf(Duck{})).BuildRule())
+
+	if horseRule.NewType != reflect.TypeOf(Horse{}) || duckRule.NewType != reflect.TypeOf(Duck{}) {
+		t.Fatalf("expected the rules to keep their types, but got %v and %v", horseRule.NewType, duckRule.NewType)
+	}
+}
@@ -2,8 +2,10 @@ package golymorph
 
 import (
 	"errors"
+	"fmt"
 	golimorphError "github.com/SoulKa/golymorph/error"
 	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
 )
 
 // RulePolymorphism is a mapper that assigns a target type based on the given Rules
@@ -14,23 +16,66 @@ type RulePolymorphism struct {
 	Rules []Rule
 }
 
+// AssignTargetType assigns the type of the first matching rule to the TargetPath in target. If the TargetPath contains
+// wildcards, the rules are evaluated for every match of the SourcePath in source, with the wildcards of their
+// ValuePath replaced by those of the match, e.g. /items/*/kind is looked up at /items/3/kind for the match
+// /items/3/payload of /items/*/payload. Value paths without wildcards are evaluated once for the whole source.
 func (p *RulePolymorphism) AssignTargetType(source any, target any) error {
+	if p.TargetPath.HasWildcards() {
+		return p.assignTargetTypes(source, target, func(sourcePath, targetPath objectpath.ObjectPath) (reflect.Type, error) {
+			rules := make([]Rule, len(p.Rules))
+			for i, rule := range p.Rules {
+				valuePath, err := rule.ValuePath.ResolveWildcards(p.sourcePath(), sourcePath)
+				if err != nil {
+					return nil, err
+				}
+				rules[i] = Rule{*valuePath, rule.ComparatorFunction, rule.NewType}
+			}
+			return p.resolveType(source, rules, targetPath)
+		})
+	}
+	newType, err := p.ResolveType(source)
+	if err != nil {
+		return err
+	}
+	return objectpath.AssignTypeAtPath(target, p.TargetPath, newType)
+}
 
-	// check for each rule if it matches and assign type if it does
+// ResolveType returns the type of the first rule that matches source. If no rule matches or a rule cannot be applied,
+// an error.UnresolvedTypeError is returned. Since a rule whose ValuePath contains wildcards may match differently for
+// every match of the TargetPath, such resolvers are rejected with an error.UnresolvedTypeError; use AssignTargetType
+// instead.
+func (p *RulePolymorphism) ResolveType(source any) (reflect.Type, error) {
 	for _, rule := range p.Rules {
-		if err, matches := rule.Matches(source); err != nil {
-			return errors.Join(errors.New("error applying rule"), err)
-		} else if matches {
-			if err := objectpath.AssignTypeAtPath(target, p.TargetPath, rule.NewType); err != nil {
-				return errors.Join(errors.New("error assigning type to target"), err)
+		if rule.ValuePath.HasWildcards() {
+			return nil, &golimorphError.UnresolvedTypeError{
+				Err:        fmt.Errorf("value path [%s] of a rule contains wildcards and may resolve a type per match", rule.ValuePath.String()),
+				TargetPath: p.TargetPath.String(),
+			}
+		}
+	}
+	return p.resolveType(source, p.Rules, p.TargetPath)
+}
+
+// resolveType returns the type of the first of the given rules that matches source. targetPath is used for error
+// messages.
+func (p *RulePolymorphism) resolveType(source any, rules []Rule, targetPath objectpath.ObjectPath) (reflect.Type, error) {
+
+	// check for each rule if it matches and return its type if it does
+	for _, rule := range rules {
+		if err, matches := rule.matches(source, p.limits()); err != nil {
+			return nil, &golimorphError.UnresolvedTypeError{
+				Err:        fmt.Errorf("error applying rule: %w", err),
+				TargetPath: targetPath.String(),
 			}
-			return nil
+		} else if matches {
+			return rule.NewType, nil
 		}
 	}
 
 	// no rule matched
-	return &golimorphError.UnresolvedTypeError{
+	return nil, &golimorphError.UnresolvedTypeError{
 		Err:        errors.New("no rule matched"),
-		TargetPath: p.TargetPath.String(),
+		TargetPath: targetPath.String(),
 	}
 }
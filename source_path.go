@@ -0,0 +1,56 @@
+package golymorph
+
+import (
+	"fmt"
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+	"strings"
+)
+
+// TranslatePath translates a path of the target type into the path of the same value in the source, using the names
+// of the given struct tag, e.g. "mapstructure" for UnmarshalJSON or "json" for UnmarshalJSONFast. Struct fields are
+// found with objectpath.FieldByName, i.e. like objectpath.GetValueAtPath finds them, and keep their name if they have no such tag. Map keys, indices,
+// wildcards and filters are kept. The type of values below interfaces and recursive wildcards is unknown, so their
+// elements are kept as well. The path must be absolute and normalized.
+func TranslatePath(path objectpath.ObjectPath, targetType reflect.Type, tagName string) (*objectpath.ObjectPath, error) {
+	if !path.IsAbsolutePath() {
+		return nil, fmt.Errorf("cannot translate path [%s]: the path must be absolute", path.String())
+	}
+	elements := []any{objectpath.ElementRoot}
+	currentType := targetType
+	for i, element := range path.Elements() {
+		for currentType != nil && currentType.Kind() == reflect.Ptr {
+			currentType = currentType.Elem()
+		}
+		if currentType == nil || currentType.Kind() == reflect.Interface || element.Type() == objectpath.ElementTypeRecursiveWildcard {
+			currentType = nil // unknown type, keep the remaining elements
+			elements = append(elements, element)
+			continue
+		}
+
+		switch currentType.Kind() {
+		case reflect.Struct:
+			if element.Type() != objectpath.ElementTypeIdentifier {
+				return nil, fmt.Errorf("cannot translate path [%s]: element at path index %d must be a field name of %v", path.String(), i, currentType)
+			}
+			field, ok := objectpath.FieldByName(currentType, element.Name())
+			if !ok {
+				return nil, fmt.Errorf("cannot translate path [%s]: field [%s] not found in %v", path.String(), element.Name(), currentType)
+			}
+			name, _, _ := strings.Cut(field.Tag.Get(tagName), ",")
+			if name == "-" {
+				return nil, fmt.Errorf("cannot translate path [%s]: field [%s] of %v is ignored by the %s tag", path.String(), field.Name, currentType, tagName)
+			} else if name == "" {
+				name = element.Name()
+			}
+			elements = append(elements, objectpath.MakeElement(name))
+			currentType = field.Type
+		case reflect.Map, reflect.Slice, reflect.Array:
+			elements = append(elements, element)
+			currentType = currentType.Elem()
+		default:
+			return nil, fmt.Errorf("cannot translate path [%s]: value at path index %d of type %v cannot be traversed", path.String(), i, currentType)
+		}
+	}
+	return objectpath.Of(elements...), nil
+}
@@ -0,0 +1,139 @@
+package golymorph
+
+import (
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+	"testing"
+)
+
+type Envelope struct {
+	Kind    string
+	Payload any `mapstructure:"data" json:"data,omitempty"`
+	Ignored any `mapstructure:"-"`
+}
+
+type Mailbox struct {
+	Envelopes []Envelope `mapstructure:"letters"`
+	ByName    map[string]*Envelope
+}
+
+func TestTranslatePath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		tagName  string
+		expected string
+	}{
+		{"/payload", "mapstructure", "/data"},
+		{"/payload", "json", "/data"},
+		{"/kind", "mapstructure", "/kind"},
+		{"/envelopes/*/payload", "mapstructure", "/letters/*/data"},
+		{`/envelopes/"3"/payload/some/thing`, "mapstructure", `/letters/"3"/data/some/thing`},
+		{"/byName/first/payload", "mapstructure", "/byName/first/data"},
+		{"/envelopes/**/payload", "mapstructure", "/letters/**/payload"},
+	}
+	for _, testCase := range testCases {
+		targetType := reflect.TypeOf(Mailbox{})
+		if testCase.path == "/payload" || testCase.path == "/kind" {
+			targetType = reflect.TypeOf(&Envelope{})
+		}
+		path := objectpath.MustParse(testCase.path)
+		if translated, err := TranslatePath(*path, targetType, testCase.tagName); err != nil {
+			t.Errorf("error translating path %s: %s", testCase.path, err)
+		} else if translated.String() != testCase.expected {
+			t.Errorf("expected path %s to be translated to %s, but got %s", testCase.path, testCase.expected, translated.String())
+		}
+	}
+}
+
+func TestTranslatePath_Error(t *testing.T) {
+	testCases := []string{"/ignored", "/unknown", "/*", "/kind/length", "relative"}
+	for _, testCase := range testCases {
+		path := objectpath.MustParse(testCase)
+		if _, err := TranslatePath(*path, reflect.TypeOf(Envelope{}), "mapstructure"); err == nil {
+			t.Errorf("expected an error translating path %s, but got none", testCase)
+		}
+	}
+}
+
+func TestTranslatePath_MatchesFieldsLikeGetValueAtPath(t *testing.T) {
+
+	// the long s folds to s with strings.EqualFold, but not with strings.ToLower
+	type Order struct {
+		Statuſ string `mapstructure:"state"`
+	}
+	path := objectpath.MustParse("/status")
+
+	var value reflect.Value
+	getErr := objectpath.GetValueAtPath(&Order{}, *path, &value)
+	_, translateErr := TranslatePath(*path, reflect.TypeOf(Order{}), "mapstructure")
+
+	if (getErr == nil) != (translateErr == nil) {
+		t.Fatalf("expected GetValueAtPath and TranslatePath to find the same fields, but got [%v] and [%v]", getErr, translateErr)
+	}
+}
+
+func TestPolymorphismBuilder_WithSourcePathFromTags(t *testing.T) {
+
+	// Arrange
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("payload").
+		WithSourcePathFromTags(Envelope{}, "mapstructure").
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+	inputJson := `{ "kind": "animal", "data": { "type": "horse", "shoes": 4 } }`
+	expected := Envelope{Kind: "animal", Payload: Horse{4}}
+
+	// Act
+	var actual Envelope
+	if err := UnmarshalJSON(resolver, []byte(inputJson), &actual); err != nil {
+		t.Fatalf("error unmarshalling envelope: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected envelope to be %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestPolymorphismBuilder_WithSourcePathAndWildcards(t *testing.T) {
+
+	// Arrange
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("envelopes/*/payload").
+		WithSourcePath("letters/*/data").
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+	inputJson := `{ "letters": [
+		{ "kind": "first", "data": { "type": "horse", "shoes": 4 } },
+		{ "kind": "second", "data": { "type": "duck", "feathers": 1000 } }
+	] }`
+	expected := Mailbox{Envelopes: []Envelope{{Kind: "first", Payload: Horse{4}}, {Kind: "second", Payload: Duck{1000}}}}
+
+	// Act
+	var actual Mailbox
+	if err := UnmarshalJSON(resolver, []byte(inputJson), &actual); err != nil {
+		t.Fatalf("error unmarshalling mailbox: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected mailbox to be %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestPolymorphismBuilder_WithMismatchingSourcePath(t *testing.T) {
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("envelopes/*/payload").
+		WithSourcePath("#/letters/0/data").
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+	inputJson := `{ "letters": [{ "data": { "type": "horse" } }] }`
+
+	var actual Mailbox
+	if err := UnmarshalJSON(resolver, []byte(inputJson), &actual); err == nil {
+		t.Fatalf("expected an error for source and target paths with different wildcards, but got none")
+	}
+}
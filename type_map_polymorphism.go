@@ -17,25 +17,97 @@ type TypeMapPolymorphism struct {
 
 	// TypeMap is a map of discriminator values to types
 	TypeMap TypeMap
+
+	// DiscriminatorMethod is the name of the method that returns the discriminator value of a variant, see MethodNaming.
+	// It is used by DiscriminatorOf. If it is empty, the discriminator value is looked up in the TypeMap.
+	DiscriminatorMethod string
 }
 
+// AssignTargetType assigns the resolved type to the TargetPath in target. If the TargetPath contains wildcards, a
+// type is resolved for every match of the SourcePath in source, with the wildcards of the DiscriminatorPath replaced
+// by those of the match, e.g. /items/*/type is looked up at /items/3/type for the match /items/3/payload of
+// /items/*/payload.
 func (p *TypeMapPolymorphism) AssignTargetType(source any, target any) error {
+	if p.TargetPath.HasWildcards() {
+		return p.assignTargetTypes(source, target, func(sourcePath, targetPath objectpath.ObjectPath) (reflect.Type, error) {
+			discriminatorPath, err := p.DiscriminatorPath.ResolveWildcards(p.sourcePath(), sourcePath)
+			if err != nil {
+				return nil, err
+			}
+			return p.resolveType(source, *discriminatorPath, targetPath)
+		})
+	}
+	newType, err := p.ResolveType(source)
+	if err != nil {
+		return err
+	}
+	return objectpath.AssignTypeAtPath(target, p.TargetPath, newType)
+}
+
+// ResolveType returns the type for the discriminator value at the DiscriminatorPath in source. If the discriminator
+// is missing, an error.DiscriminatorMissingError is returned, if it is unknown, an error.UnresolvedTypeError. Since
+// a DiscriminatorPath with wildcards may yield a different type for every match, such resolvers are rejected with
+// an error.UnresolvedTypeError; use AssignTargetType instead.
+func (p *TypeMapPolymorphism) ResolveType(source any) (reflect.Type, error) {
+	if p.DiscriminatorPath.HasWildcards() {
+		return nil, &golimorphError.UnresolvedTypeError{
+			Err:        fmt.Errorf("discriminator path [%s] contains wildcards and may resolve a type per match", p.DiscriminatorPath.String()),
+			TargetPath: p.TargetPath.String(),
+		}
+	}
+	return p.resolveType(source, p.DiscriminatorPath, p.TargetPath)
+}
+
+// resolveType returns the type for the discriminator value at discriminatorPath. targetPath is used for error messages.
+func (p *TypeMapPolymorphism) resolveType(source any, discriminatorPath objectpath.ObjectPath, targetPath objectpath.ObjectPath) (reflect.Type, error) {
 
 	// get discriminator value
 	var discriminatorValue reflect.Value
-	if err := objectpath.GetValueAtPath(source, p.DiscriminatorPath, &discriminatorValue); err != nil {
-		return errors.Join(errors.New("error getting discriminator value"), err)
+	if err := objectpath.GetValueAtPathWithLimits(source, discriminatorPath, p.limits(), &discriminatorValue); err != nil {
+		return nil, &golimorphError.DiscriminatorMissingError{DiscriminatorPath: discriminatorPath.String(), Err: err}
+	}
+	var rawDiscriminatorValue any
+	if discriminatorValue.IsValid() {
+		rawDiscriminatorValue = discriminatorValue.Interface()
 	}
-	rawDiscriminatorValue := discriminatorValue.Interface()
 
 	// get type from type map
-	if newType, ok := p.TypeMap[rawDiscriminatorValue]; !ok {
-		return &golimorphError.UnresolvedTypeError{
-			Err:        fmt.Errorf("type map does not contain any key of value [%+v]", rawDiscriminatorValue),
-			TargetPath: p.TargetPath.String(),
+	newType, ok := p.TypeMap.lookup(rawDiscriminatorValue)
+	if !ok {
+		return nil, &golimorphError.UnresolvedTypeError{
+			Err:        &golimorphError.UnknownDiscriminatorError{Value: rawDiscriminatorValue, KnownKeys: p.TypeMap.Keys()},
+			TargetPath: targetPath.String(),
+		}
+	}
+	return newType, nil
+}
+
+// DiscriminatorOf returns the discriminator value of the given variant. This is the inverse of ResolveType and
+// is used when marshalling. The value is obtained from DiscriminatorMethod if it is set, otherwise it is the first
+// key of the TypeMap whose type is the type of the variant. A value of DiscriminatorMethod that is no key of the
+// TypeMap returns an error.UnknownDiscriminatorError, a key of another type an error.VariantMismatchError, since
+// ResolveType could not resolve the type of the variant again.
+func (p *TypeMapPolymorphism) DiscriminatorOf(variant any) (any, error) {
+	if variant == nil {
+		return nil, errors.New("cannot determine discriminator value of nil")
+	}
+	variantType := reflect.TypeOf(variant)
+	if p.DiscriminatorMethod != "" {
+		key, err := callDiscriminatorMethod(reflect.ValueOf(variant), p.DiscriminatorMethod)
+		if err != nil {
+			return nil, err
+		}
+		if newType, ok := p.TypeMap.lookup(key); !ok {
+			return nil, &golimorphError.UnknownDiscriminatorError{Value: key, KnownKeys: p.TypeMap.Keys()}
+		} else if newType != variantType {
+			return nil, &golimorphError.VariantMismatchError{Expected: newType, Actual: variantType}
+		}
+		return key, nil
+	}
+	for _, key := range p.TypeMap.Keys() {
+		if p.TypeMap[key] == variantType {
+			return key, nil
 		}
-	} else if err := objectpath.AssignTypeAtPath(target, p.TargetPath, newType); err != nil {
-		return errors.Join(errors.New("error assigning type to target"), err)
 	}
-	return nil
+	return nil, fmt.Errorf("type map does not contain type %v", variantType)
 }
@@ -2,13 +2,44 @@ package golymorph
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/mitchellh/mapstructure"
 	"reflect"
+	"sort"
 )
 
 // TypeMap is a map of values to types.
 type TypeMap map[any]reflect.Type
 
+// Keys returns the keys of the TypeMap sorted by their string representation.
+func (m TypeMap) Keys() []any {
+	keys := make([]any, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+	return keys
+}
+
+// lookup returns the type of the given discriminator value. Values that cannot be map keys, e.g. decoded JSON
+// objects and arrays, are no key of any TypeMap.
+func (m TypeMap) lookup(key any) (reflect.Type, bool) {
+	if !isComparable(key) {
+		return nil, false
+	}
+	newType, ok := m[key]
+	return newType, ok
+}
+
+// isComparable returns true if value may be used as a map key
+func isComparable(value any) bool {
+	switch value.(type) {
+	case nil, string, float64, bool, int:
+		return true // fast path for decoded JSON values
+	}
+	return reflect.ValueOf(value).Comparable()
+}
+
 // TypeResolver is an interface that can resolve the type of a target based on the values of a source.
 type TypeResolver interface {
 	// AssignTargetType assigns the determined type to target based on the polymorphism rules. The matching rule with the
@@ -17,6 +48,14 @@ type TypeResolver interface {
 	AssignTargetType(source any, target any) error
 }
 
+// TypeDeterminer is an interface that can determine the type of a target based on the values of a source without
+// assigning it, e.g. for routing or selecting a handler. It is implemented by TypeMapPolymorphism and RulePolymorphism.
+type TypeDeterminer interface {
+	// ResolveType returns the type that AssignTargetType would assign to the target. The source must be a pointer.
+	// If no matching type can be determined, an error.UnresolvedTypeError is returned.
+	ResolveType(source any) (reflect.Type, error)
+}
+
 // UnmarshalJSON unmarshals the given JSON data into the given output object using the given TypeResolver.
 func UnmarshalJSON(resolver TypeResolver, data []byte, output any) error {
 
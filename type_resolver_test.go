@@ -2,6 +2,8 @@ package golymorph
 
 import (
 	"encoding/json"
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
 	"github.com/SoulKa/golymorph/objectpath"
 	"github.com/mitchellh/mapstructure"
 	"reflect"
@@ -83,3 +85,172 @@ func TestPolymorphism_AssignTargetType(t *testing.T) {
 		}
 	}
 }
+
+func TestTypeMapPolymorphism_AssignTargetTypeWithError(t *testing.T) {
+	type TestCase struct {
+		inputJson string
+		sentinels []error
+	}
+	testCases := []TestCase{
+		{`{ "name": "snakey", "specifics": { "type": "snake" } }`, []error{golimorphError.ErrUnresolvedType, golimorphError.ErrUnknownDiscriminator}},
+		{`{ "name": "snakey", "specifics": { "type": null } }`, []error{golimorphError.ErrUnresolvedType, golimorphError.ErrUnknownDiscriminator}},
+		{`{ "name": "snakey", "specifics": { "type": { "x": 1 } } }`, []error{golimorphError.ErrUnresolvedType, golimorphError.ErrUnknownDiscriminator}},
+		{`{ "name": "snakey", "specifics": { "type": [1] } }`, []error{golimorphError.ErrUnresolvedType, golimorphError.ErrUnknownDiscriminator}},
+		{`{ "name": "snakey", "specifics": {} }`, []error{golimorphError.ErrDiscriminatorMissing, golimorphError.ErrPathNotFound}},
+		{`{ "name": "snakey", "specifics": "snake" }`, []error{golimorphError.ErrDiscriminatorMissing, golimorphError.ErrNotTraversable}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.inputJson, func(t *testing.T) {
+
+			// Act
+			var animal, fastAnimal Animal
+			err := UnmarshalJSON(animalPolymorphism, []byte(tc.inputJson), &animal)
+			fastErr := UnmarshalJSONFast(animalPolymorphism, []byte(tc.inputJson), &fastAnimal)
+
+			// Assert
+			for _, sentinel := range tc.sentinels {
+				if !errors.Is(err, sentinel) {
+					t.Fatalf("expected error [%v] to match [%v]", err, sentinel)
+				} else if !errors.Is(fastErr, sentinel) {
+					t.Fatalf("expected error [%v] of UnmarshalJSONFast to match [%v]", fastErr, sentinel)
+				}
+			}
+		})
+	}
+}
+
+func TestTypeMapPolymorphism_AssignTargetTypeNotAssignable(t *testing.T) {
+	type Stable struct {
+		Specifics Duck
+	}
+	var stable Stable
+	source := map[string]any{"specifics": map[string]any{"type": "horse"}}
+
+	err := animalPolymorphism.AssignTargetType(&source, &stable)
+
+	var typeError *golimorphError.TypeNotAssignableError
+	if !errors.As(err, &typeError) {
+		t.Fatalf("expected a TypeNotAssignableError, but got %v", err)
+	} else if typeError.Type != reflect.TypeOf(Horse{}) {
+		t.Fatalf("expected type to be Horse, but got %v", typeError.Type)
+	}
+}
+
+func TestUnknownDiscriminatorError(t *testing.T) {
+
+	// Act
+	var animal Animal
+	err := UnmarshalJSON(animalPolymorphism, []byte(`{ "name": "snakey", "specifics": { "type": "snake" } }`), &animal)
+
+	// Assert
+	var unknownDiscriminatorError *golimorphError.UnknownDiscriminatorError
+	if !errors.As(err, &unknownDiscriminatorError) {
+		t.Fatalf("expected error to be an UnknownDiscriminatorError, but got %v", err)
+	} else if unknownDiscriminatorError.Value != "snake" {
+		t.Fatalf(`expected value to be "snake", but got %v`, unknownDiscriminatorError.Value)
+	} else if !reflect.DeepEqual(unknownDiscriminatorError.KnownKeys, []any{"duck", "horse"}) {
+		t.Fatalf("expected known keys to be [duck horse], but got %v", unknownDiscriminatorError.KnownKeys)
+	}
+}
+
+func TestTypeDeterminer_ResolveType(t *testing.T) {
+	horseRule := Must(NewRuleBuilder().
+		WhenValueAt("/specifics/shoes").
+		Matches(func(v any) bool { return v != nil }).
+		ThenAssignType(reflect.TypeOf(Horse{})).
+		BuildRule())
+	determiners := map[string]TypeDeterminer{
+		"TypeMapPolymorphism": animalPolymorphism.(TypeDeterminer),
+		"RulePolymorphism":    Must(NewPolymorphismBuilder().DefineTypeAt("specifics").UsingRule(horseRule).BuildResolver()).(TypeDeterminer),
+	}
+
+	for name, determiner := range determiners {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+			var source map[string]any
+			if err := json.Unmarshal([]byte(testCases[0].inputJson), &source); err != nil {
+				t.Fatalf("error unmarshalling horse: %s", err)
+			}
+
+			// Act
+			newType, err := determiner.ResolveType(&source)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("error resolving type: %s", err)
+			} else if newType != reflect.TypeOf(Horse{}) {
+				t.Fatalf("expected type to be Horse, but got %v", newType)
+			}
+		})
+	}
+}
+
+func TestTypeDeterminer_ResolveTypeWithError(t *testing.T) {
+	source := map[string]any{"animals": []any{map[string]any{"specifics": map[string]any{"type": "horse"}}}}
+	wildcardResolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("animals/*/specifics").
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver()).(TypeDeterminer)
+	failingRule := Rule{*objectpath.MustParse("/unknown"), func(any) bool { return true }, reflect.TypeOf(Horse{})}
+	ruleResolver := Must(NewPolymorphismBuilder().DefineTypeAt("specifics").UsingRule(failingRule).BuildResolver()).(TypeDeterminer)
+
+	if _, err := wildcardResolver.ResolveType(&source); !errors.Is(err, golimorphError.ErrUnresolvedType) {
+		t.Errorf("expected an UnresolvedTypeError for a wildcard discriminator path, but got %v", err)
+	}
+	if _, err := ruleResolver.ResolveType(&source); !errors.Is(err, golimorphError.ErrUnresolvedType) || !errors.Is(err, golimorphError.ErrPathNotFound) {
+		t.Errorf("expected an UnresolvedTypeError caused by a PathNotFoundError, but got %v", err)
+	}
+}
+
+func TestPolymorphism_AssignTargetTypeWithFilter(t *testing.T) {
+	inputJson := `{ "name": "ducky", "attributes": [ { "name": "color", "value": "white" }, { "name": "kind", "value": "duck" } ], "specifics": { "feathers": 1000 } }`
+	expected := Animal{"ducky", Duck{1000}}
+
+	t.Run("type map", func(t *testing.T) {
+		resolver := Must(NewPolymorphismBuilder().
+			DefineTypeAt("specifics").
+			UsingTypeMap(animalTypeMap).
+			WithDiscriminatorAt(`../attributes[?(@.name=="kind")]/value`).
+			BuildResolver())
+
+		var actual Animal
+		if err := UnmarshalJSON(resolver, []byte(inputJson), &actual); err != nil {
+			t.Fatalf("error unmarshalling animal: %s", err)
+		} else if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected animal to be %+v, but got %+v", expected, actual)
+		}
+	})
+
+	t.Run("rule", func(t *testing.T) {
+		rule := Must(NewRuleBuilder().
+			WhenValueAt(`/attributes[?(@.name=="kind")]/value`).
+			IsEqualTo("duck").
+			ThenAssignType(reflect.TypeOf(Duck{})).
+			BuildRule())
+		resolver := Must(NewPolymorphismBuilder().DefineTypeAt("specifics").UsingRule(rule).BuildResolver())
+
+		var actual Animal
+		if err := UnmarshalJSON(resolver, []byte(inputJson), &actual); err != nil {
+			t.Fatalf("error unmarshalling animal: %s", err)
+		} else if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected animal to be %+v, but got %+v", expected, actual)
+		}
+	})
+}
+
+func TestRulePolymorphism_AssignTargetTypeWithNull(t *testing.T) {
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("specifics").
+		UsingRule(Must(NewRuleBuilder().WhenValueAt("/specifics/type").IsEqualTo("horse").ThenAssignType(reflect.TypeOf(Horse{})).BuildRule())).
+		BuildResolver())
+
+	var animal Animal
+	err := UnmarshalJSON(resolver, []byte(`{ "name": "nobody", "specifics": { "type": null } }`), &animal)
+
+	if !errors.Is(err, golimorphError.ErrUnresolvedType) {
+		t.Fatalf("expected an UnresolvedTypeError for a null value, but got %v", err)
+	}
+}
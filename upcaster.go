@@ -0,0 +1,129 @@
+package golymorph
+
+import (
+	"errors"
+	"fmt"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+)
+
+// UpcastFunc transforms a source map of one schema version into the next version. It may modify and return the
+// given map or return a new one.
+type UpcastFunc func(source map[string]any) (map[string]any, error)
+
+// upcastStep is a registered transformation from one version to the next
+type upcastStep struct {
+	to     any
+	upcast UpcastFunc
+}
+
+// Upcaster is a TypeResolver that migrates old versions of a source map to the current version before resolving
+// the type with Resolver. The version is read from VersionPath; a missing or null version is the version nil.
+// Numeric versions are compared by value, so 1 matches the JSON number 1.
+type Upcaster struct {
+	// VersionPath is the absolute path to the version discriminator in the source
+	VersionPath objectpath.ObjectPath
+	// Resolver is the TypeResolver that is applied to the migrated source
+	Resolver TypeResolver
+
+	steps map[any]upcastStep
+}
+
+// NewUpcaster creates a new Upcaster that reads the version at versionPath and resolves the migrated source with
+// resolver. Relative version paths are relative to the root of the source, see objectpath.Parse.
+func NewUpcaster(versionPath string, resolver TypeResolver) (*Upcaster, error) {
+	path, err := objectpath.Parse(versionPath)
+	if err != nil {
+		return nil, err
+	} else if err := path.ToAbsolutePath(objectpath.NewRootPath()); err != nil {
+		return nil, err
+	}
+	return &Upcaster{VersionPath: *path, Resolver: resolver, steps: make(map[any]upcastStep)}, nil
+}
+
+// Register registers upcast as the transformation from version from to version to. After upcast ran, the version
+// at VersionPath is set to to. Only one transformation may be registered per version.
+func (u *Upcaster) Register(from any, to any, upcast UpcastFunc) error {
+	from = normalizeVersion(from)
+	if !isComparable(from) {
+		return fmt.Errorf("version [%v] of type %T cannot be registered: versions must be comparable", from, from)
+	} else if u.steps == nil {
+		u.steps = make(map[any]upcastStep)
+	}
+	if _, ok := u.steps[from]; ok {
+		return fmt.Errorf("an upcaster from version [%v] is already registered", from)
+	}
+	u.steps[from] = upcastStep{to, upcast}
+	return nil
+}
+
+// Upcast migrates the given source to the latest version by applying the registered transformations in order.
+func (u *Upcaster) Upcast(source *map[string]any) error {
+	for i := 0; ; i++ {
+		version, err := u.version(source)
+		if err != nil {
+			return err
+		}
+		step, ok := u.steps[version]
+		if !ok {
+			return nil // latest version
+		} else if i >= len(u.steps) {
+			return fmt.Errorf("cannot upcast: the upcasters from version [%v] form a cycle", version)
+		}
+
+		// apply the transformation and set the new version
+		upcasted, err := step.upcast(*source)
+		if err != nil {
+			return fmt.Errorf("error upcasting from version [%v] to [%v]: %w", version, step.to, err)
+		} else if upcasted == nil {
+			return fmt.Errorf("error upcasting from version [%v] to [%v]: the upcaster returned nil", version, step.to)
+		}
+		*source = upcasted
+		if err := objectpath.SetValueAtPath(source, u.VersionPath, step.to); err != nil {
+			return err
+		}
+	}
+}
+
+// version returns the normalized version of the source. A missing or null version is nil.
+func (u *Upcaster) version(source *map[string]any) (any, error) {
+	var value reflect.Value
+	if err := objectpath.GetValueAtPath(source, u.VersionPath, &value); errors.Is(err, golimorphError.ErrPathNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if !value.IsValid() {
+		return nil, nil
+	}
+	version := normalizeVersion(value.Interface())
+	if !isComparable(version) {
+		return nil, fmt.Errorf("cannot upcast: version [%v] of type %T is not comparable", version, version)
+	}
+	return version, nil
+}
+
+func (u *Upcaster) AssignTargetType(source any, target any) error {
+	sourceMap, ok := source.(*map[string]any)
+	if !ok {
+		return fmt.Errorf("cannot upcast source of type %T: source must be a *map[string]any", source)
+	} else if err := u.Upcast(sourceMap); err != nil {
+		return err
+	}
+	return u.Resolver.AssignTargetType(source, target)
+}
+
+// normalizeVersion converts numeric versions to float64, the type of JSON numbers.
+func normalizeVersion(version any) any {
+	value := reflect.ValueOf(version)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	default:
+		return version
+	}
+}
@@ -0,0 +1,105 @@
+package golymorph
+
+import (
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newAnimalUpcaster returns an Upcaster for animals of the versions
+// nil: { "name": "horsey", "kind": "horse", "shoes": 4 }
+// 1:   { "name": "horsey", "specifics": { "kind": "horse", "shoes": 4 } }
+// 2:   { "name": "horsey", "specifics": { "type": "horse", "shoes": 4 } }
+func newAnimalUpcaster(t *testing.T) *Upcaster {
+	upcaster, err := NewUpcaster("version", animalPolymorphism)
+	if err != nil {
+		t.Fatalf("error creating upcaster: %s", err)
+	}
+	if err := upcaster.Register(nil, 1, func(source map[string]any) (map[string]any, error) {
+		specifics := make(map[string]any)
+		for key, value := range source {
+			if key != "name" {
+				specifics[key] = value
+			}
+		}
+		return map[string]any{"name": source["name"], "specifics": specifics}, nil
+	}); err != nil {
+		t.Fatalf("error registering upcaster: %s", err)
+	}
+	if err := upcaster.Register(1, 2, func(source map[string]any) (map[string]any, error) {
+		specifics := source["specifics"].(map[string]any)
+		specifics["type"] = specifics["kind"]
+		delete(specifics, "kind")
+		return source, nil
+	}); err != nil {
+		t.Fatalf("error registering upcaster: %s", err)
+	}
+	return upcaster
+}
+
+func TestUpcaster_AssignTargetType(t *testing.T) {
+	upcaster := newAnimalUpcaster(t)
+	inputs := []string{
+		`{ "name": "horsey", "kind": "horse", "shoes": 4 }`,
+		`{ "version": 1, "name": "horsey", "specifics": { "kind": "horse", "shoes": 4 } }`,
+		`{ "version": 2, "name": "horsey", "specifics": { "type": "horse", "shoes": 4 } }`,
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			animal, err := Unmarshal[Animal](upcaster, []byte(input))
+			if err != nil {
+				t.Fatalf("error unmarshalling animal: %s", err)
+			} else if expected := (Animal{"horsey", Horse{4}}); !reflect.DeepEqual(animal, expected) {
+				t.Fatalf("expected animal to be %+v, but got %+v", expected, animal)
+			}
+		})
+	}
+}
+
+func TestUpcaster_RegisterWithError(t *testing.T) {
+	upcaster := newAnimalUpcaster(t)
+	if err := upcaster.Register(float64(1), 3, nil); err == nil || err.Error() != "an upcaster from version [1] is already registered" {
+		t.Fatalf("expected error for duplicate version, but got %v", err)
+	}
+}
+
+func TestUpcaster_UpcastWithCycle(t *testing.T) {
+	upcaster := Must(NewUpcaster("version", animalPolymorphism))
+	identity := func(source map[string]any) (map[string]any, error) { return source, nil }
+	_ = upcaster.Register(1, 2, identity)
+	_ = upcaster.Register(2, 1, identity)
+
+	source := map[string]any{"version": 1}
+	if err := upcaster.Upcast(&source); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected error for cyclic upcasters, but got %v", err)
+	}
+}
+
+func TestUpcaster_RegisterOnLiteral(t *testing.T) {
+	upcaster := &Upcaster{VersionPath: *objectpath.MustParse("/version"), Resolver: animalPolymorphism}
+	if err := upcaster.Register(nil, 2, func(source map[string]any) (map[string]any, error) {
+		source["specifics"] = map[string]any{"type": source["kind"]}
+		return source, nil
+	}); err != nil {
+		t.Fatalf("error registering upcaster: %s", err)
+	}
+
+	animal, err := Unmarshal[Animal](upcaster, []byte(`{ "name": "horsey", "kind": "horse" }`))
+	if err != nil {
+		t.Fatalf("error unmarshalling animal: %s", err)
+	} else if expected := (Animal{"horsey", Horse{}}); !reflect.DeepEqual(animal, expected) {
+		t.Fatalf("expected animal to be %+v, but got %+v", expected, animal)
+	}
+}
+
+func TestUpcaster_UpcastWithIncomparableVersion(t *testing.T) {
+	upcaster := newAnimalUpcaster(t)
+	if err := upcaster.Register([]int{1}, 2, nil); err == nil {
+		t.Errorf("expected an error registering an incomparable version, but got none")
+	}
+	if _, err := Unmarshal[Animal](upcaster, []byte(`{ "version": { "major": 1 }, "name": "horsey" }`)); err == nil {
+		t.Errorf("expected an error upcasting an incomparable version, but got none")
+	}
+}
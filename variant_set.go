@@ -0,0 +1,79 @@
+package golymorph
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// VariantSet declares an interface, usually a sealed one with an unexported marker method, and the types that are
+// permitted to implement it. It is used to verify that a TypeMap covers exactly the permitted variants.
+type VariantSet struct {
+	// Interface is the interface type of the polymorphic value
+	Interface reflect.Type
+	// Variants are the permitted implementations of Interface
+	Variants []reflect.Type
+}
+
+// NewVariantSet creates a new VariantSet for the interface I. It returns an error if I is not an interface or a
+// variant is nil or does not implement I.
+func NewVariantSet[I any](variants ...reflect.Type) (*VariantSet, error) {
+	set := &VariantSet{Interface: reflect.TypeOf((*I)(nil)).Elem(), Variants: variants}
+	if set.Interface.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("cannot create variant set: %v is not an interface", set.Interface)
+	}
+	var errs []error
+	for i, variant := range variants {
+		if variant == nil {
+			errs = append(errs, fmt.Errorf("variant at index %d is nil", i))
+		} else if !variant.Implements(set.Interface) {
+			errs = append(errs, fmt.Errorf("variant %v does not implement %v", variant, set.Interface))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return set, nil
+}
+
+// Contains returns true if t is a permitted variant of the VariantSet.
+func (s *VariantSet) Contains(t reflect.Type) bool {
+	for _, variant := range s.Variants {
+		if variant == t {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyTypeMap returns an error if a type of the TypeMap does not implement the interface or is not a permitted
+// variant of the VariantSet.
+func (s *VariantSet) VerifyTypeMap(typeMap TypeMap) error {
+	var errs []error
+	for _, key := range typeMap.Keys() {
+		if newType := typeMap[key]; newType == nil {
+			errs = append(errs, fmt.Errorf("type of key [%+v] is nil", key))
+		} else if !newType.Implements(s.Interface) {
+			errs = append(errs, fmt.Errorf("type %v of key [%+v] does not implement %v", newType, key, s.Interface))
+		} else if !s.Contains(newType) {
+			errs = append(errs, fmt.Errorf("type %v of key [%+v] is not a registered variant of %v", newType, key, s.Interface))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// VerifyExhaustive returns an error if the TypeMap does not cover exactly the variants of the VariantSet, i.e. if
+// VerifyTypeMap fails or a variant has no discriminator value in the TypeMap.
+func (s *VariantSet) VerifyExhaustive(typeMap TypeMap) error {
+	errs := []error{s.VerifyTypeMap(typeMap)}
+	mapped := make(map[reflect.Type]bool, len(typeMap))
+	for _, newType := range typeMap {
+		mapped[newType] = true
+	}
+	for _, variant := range s.Variants {
+		if !mapped[variant] {
+			errs = append(errs, fmt.Errorf("variant %v of %v has no discriminator value in the type map", variant, s.Interface))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,66 @@
+package golymorph
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Pet interface {
+	isPet()
+}
+
+type Cat struct{}
+
+func (Cat) isPet() {}
+
+type Dog struct{}
+
+func (Dog) isPet() {}
+
+type Fish struct{}
+
+func (Fish) isPet() {}
+
+var petVariants = Must(NewVariantSet[Pet](reflect.TypeOf(Cat{}), reflect.TypeOf(Dog{})))
+
+func TestNewVariantSetWithError(t *testing.T) {
+	if _, err := NewVariantSet[Pet](reflect.TypeOf(Horse{})); err == nil {
+		t.Fatalf("expected an error for a variant that does not implement the interface")
+	}
+	if _, err := NewVariantSet[Pet](reflect.TypeOf(Cat{}), nil); err == nil {
+		t.Fatalf("expected an error for a nil variant")
+	}
+	if _, err := NewVariantSet[Horse](); err == nil {
+		t.Fatalf("expected an error for a type that is not an interface")
+	}
+}
+
+func TestVariantSet_VerifyExhaustive(t *testing.T) {
+	type TestCase struct {
+		name    string
+		typeMap TypeMap
+		error   string
+	}
+	testCases := []TestCase{
+		{"exhaustive", TypeMap{"cat": reflect.TypeOf(Cat{}), "dog": reflect.TypeOf(Dog{})}, ""},
+		{"missing variant", TypeMap{"cat": reflect.TypeOf(Cat{})},
+			"variant golymorph.Dog of golymorph.Pet has no discriminator value in the type map"},
+		{"unregistered variant", TypeMap{"cat": reflect.TypeOf(Cat{}), "dog": reflect.TypeOf(Dog{}), "fish": reflect.TypeOf(Fish{})},
+			"type golymorph.Fish of key [fish] is not a registered variant of golymorph.Pet"},
+		{"not implementing", TypeMap{"cat": reflect.TypeOf(Cat{}), "dog": reflect.TypeOf(Dog{}), "horse": reflect.TypeOf(Horse{})},
+			"type golymorph.Horse of key [horse] does not implement golymorph.Pet"},
+		{"nil type", TypeMap{"cat": reflect.TypeOf(Cat{}), "dog": reflect.TypeOf(Dog{}), "nothing": nil},
+			"type of key [nothing] is nil"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := petVariants.VerifyExhaustive(tc.typeMap)
+			if tc.error == "" && err != nil {
+				t.Fatalf("expected no error, but got %s", err)
+			} else if tc.error != "" && (err == nil || err.Error() != tc.error) {
+				t.Fatalf("expected error to be [%s], but got [%v]", tc.error, err)
+			}
+		})
+	}
+}
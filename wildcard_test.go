@@ -0,0 +1,166 @@
+package golymorph
+
+import (
+	"errors"
+	golimorphError "github.com/SoulKa/golymorph/error"
+	"github.com/SoulKa/golymorph/objectpath"
+	"reflect"
+	"testing"
+)
+
+type Herd struct {
+	Animals []Animal
+}
+
+func TestTypeMapPolymorphism_AssignTargetTypeWithWildcards(t *testing.T) {
+
+	// Arrange
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("animals/*/specifics").
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+	inputJson := `{ "animals": [
+		{ "name": "Horsey", "specifics": { "type": "horse", "shoes": 4 } },
+		{ "name": "Ducky", "specifics": { "type": "duck", "feathers": 1000 } }
+	] }`
+	expected := Herd{[]Animal{{"Horsey", Horse{4}}, {"Ducky", Duck{1000}}}}
+
+	// Act
+	var actual Herd
+	if err := UnmarshalJSON(resolver, []byte(inputJson), &actual); err != nil {
+		t.Fatalf("error unmarshalling herd: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected herd to be %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestTypeMapPolymorphism_AssignTargetTypeWithWildcardsAndError(t *testing.T) {
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("animals/*/specifics").
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+	inputJson := `{ "animals": [
+		{ "specifics": { "type": "snake" } },
+		{ "specifics": { "type": "horse" } },
+		{ "specifics": {} }
+	] }`
+
+	var herd Herd
+	err := UnmarshalJSON(resolver, []byte(inputJson), &herd)
+
+	var aggregateError *golimorphError.AggregateError
+	if !errors.As(err, &aggregateError) {
+		t.Fatalf("expected an AggregateError, but got %v", err)
+	} else if locations := aggregateError.Locations(); !reflect.DeepEqual(locations, []string{"/animals/0/specifics", "/animals/2/specifics"}) {
+		t.Fatalf("expected locations to be [/animals/0/specifics /animals/2/specifics], but got %v", locations)
+	} else if !errors.Is(err, golimorphError.ErrUnknownDiscriminator) || !errors.Is(err, golimorphError.ErrDiscriminatorMissing) {
+		t.Fatalf("expected the errors of both animals, but got %v", err)
+	}
+}
+
+func TestRulePolymorphism_AssignTargetTypeWithRecursiveWildcard(t *testing.T) {
+
+	// Arrange
+	rule := Must(NewRuleBuilder().WhenValueAt("kind").IsEqualTo("ducks").ThenAssignType(reflect.TypeOf(Duck{})).BuildRule())
+	resolver := Must(NewPolymorphismBuilder().DefineTypeAt("**/specifics").UsingRule(rule).BuildResolver())
+	inputJson := `{ "kind": "ducks", "animals": [ { "specifics": { "feathers": 1 } }, { "specifics": { "feathers": 2 } } ] }`
+	expected := Herd{[]Animal{{"", Duck{1}}, {"", Duck{2}}}}
+
+	// Act
+	var actual Herd
+	if err := UnmarshalJSON(resolver, []byte(inputJson), &actual); err != nil {
+		t.Fatalf("error unmarshalling herd: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected herd to be %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestRulePolymorphism_AssignTargetTypeWithWildcards(t *testing.T) {
+
+	// Arrange
+	horseRule := Must(NewRuleBuilder().WhenValueAt("/animals/*/specifics/type").IsEqualTo("horse").ThenAssignType(reflect.TypeOf(Horse{})).BuildRule())
+	duckRule := Must(NewRuleBuilder().WhenValueAt("/animals/*/specifics/type").IsEqualTo("duck").ThenAssignType(reflect.TypeOf(Duck{})).BuildRule())
+	resolver := Must(NewPolymorphismBuilder().DefineTypeAt("animals/*/specifics").UsingRule(horseRule).UsingRule(duckRule).BuildResolver())
+	inputJson := `{ "animals": [
+		{ "name": "horsey", "specifics": { "type": "horse", "shoes": 4 } },
+		{ "name": "ducky", "specifics": { "type": "duck", "feathers": 2 } }
+	] }`
+	expected := Herd{[]Animal{{"horsey", Horse{4}}, {"ducky", Duck{2}}}}
+
+	// Act
+	var actual Herd
+	if err := UnmarshalJSON(resolver, []byte(inputJson), &actual); err != nil {
+		t.Fatalf("error unmarshalling herd: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected herd to be %+v, but got %+v", expected, actual)
+	} else if _, err := resolver.(*RulePolymorphism).ResolveType(map[string]any{}); !errors.Is(err, golimorphError.ErrUnresolvedType) {
+		t.Fatalf("expected ResolveType to reject rules with wildcards, but got %v", err)
+	}
+}
+
+func TestTypeMapPolymorphism_AssignTargetTypeWithMapKeyAsIndex(t *testing.T) {
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("animals/*/specifics").
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+	inputJson := `{ "animals": { "20000000": { "specifics": { "type": "horse" } } } }`
+
+	var herd Herd
+	err := UnmarshalJSON(resolver, []byte(inputJson), &herd)
+
+	if err == nil {
+		t.Fatalf("expected an error for a map key that is used as slice index, but got none")
+	} else if len(herd.Animals) > 0 {
+		t.Fatalf("expected the slice not to grow, but it has %d elements", len(herd.Animals))
+	}
+}
+
+func TestTypeMapPolymorphism_AssignTargetTypeWithLimits(t *testing.T) {
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("animals/*/specifics").
+		WithLimits(objectpath.Limits{MaxElements: 2}).
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt("type").
+		BuildResolver())
+	inputJson := `{ "animals": [
+		{ "specifics": { "type": "horse" } },
+		{ "specifics": { "type": "horse" } },
+		{ "specifics": { "type": "duck" } }
+	] }`
+
+	var herd Herd
+	err := UnmarshalJSON(resolver, []byte(inputJson), &herd)
+
+	if !errors.Is(err, golimorphError.ErrLimitExceeded) {
+		t.Fatalf("expected a LimitExceededError, but got %v", err)
+	}
+}
+
+func TestTypeMapPolymorphism_ResolveTypeWithLimits(t *testing.T) {
+	resolver := Must(NewPolymorphismBuilder().
+		DefineTypeAt("specifics").
+		WithLimits(objectpath.Limits{MaxElements: 1}).
+		UsingTypeMap(animalTypeMap).
+		WithDiscriminatorAt(`/attributes[?(@.name=="kind")]/value`).
+		BuildResolver())
+	inputJson := `{ "attributes": [ { "name": "color", "value": "brown" }, { "name": "kind", "value": "horse" } ], "specifics": {} }`
+
+	var animal Animal
+	err := UnmarshalJSON(resolver, []byte(inputJson), &animal)
+
+	if !errors.Is(err, golimorphError.ErrLimitExceeded) {
+		t.Fatalf("expected a LimitExceededError, but got %v", err)
+	}
+}